@@ -4,10 +4,13 @@ import (
 	"WordMaster/models"
 	"WordMaster/services"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -21,6 +24,15 @@ type App struct {
 	dataDir      string
 	audioDir     string
 	imageDir     string
+	dataSource   string // 数据目录的来源
+	configPath   string // 修改数据目录时写入的配置文件
+	dataDirErr   error  // 无法确定数据目录时的错误
+
+	// servicesMu 保护各个服务和数据目录：绑定方法和后台任务使用服务期间持有读锁，
+	// 移动数据目录和恢复备份(其间数据库会关闭)、切换学习者和修改学习设置时持有写锁
+	servicesMu sync.RWMutex
+
+	legacyImport *models.LegacyImportResult // 启动时迁移旧版 words.json 的结果，页面加载后通知前端
 }
 
 // convertToFileFilters 将map[string][]string转换为[]runtime.FileFilter
@@ -42,24 +54,25 @@ func convertToFileFilters(filtersMap map[string][]string) []runtime.FileFilter {
 	return fileFilters
 }
 
-// NewApp creates a new App application struct
+// NewApp 创建应用，数据目录可以通过命令行参数、环境变量、便携模式或配置文件指定
 func NewApp() *App {
-	// 获取应用数据目录
-	homeDir, err := os.UserHomeDir()
+	app := &App{}
+	location, err := resolveDataDir(os.Args[1:])
 	if err != nil {
-		homeDir = "."
+		app.dataDirErr = err
+		return app
 	}
+	app.setDataDir(location.dir)
+	app.dataSource = location.source
+	app.configPath = location.configPath
+	return app
+}
 
-	// 创建应用数据目录
-	dataDir := filepath.Join(homeDir, ".wordmaster")
-	audioDir := filepath.Join(dataDir, "audio")
-	imageDir := filepath.Join(dataDir, "images")
-
-	return &App{
-		dataDir:  dataDir,
-		audioDir: audioDir,
-		imageDir: imageDir,
-	}
+// setDataDir 设置数据目录及其中的音频和图片目录
+func (a *App) setDataDir(dataDir string) {
+	a.dataDir = dataDir
+	a.audioDir = filepath.Join(dataDir, "audio")
+	a.imageDir = filepath.Join(dataDir, "images")
 }
 
 // startup is called when the app starts. The context is saved
@@ -67,33 +80,106 @@ func NewApp() *App {
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
+	if a.dataDirErr != nil {
+		runtime.LogErrorf(ctx, "Failed to determine data directory: %v", a.dataDirErr)
+		return
+	}
+	runtime.LogInfof(ctx, "Using data directory %s (%s)", a.dataDir, a.dataSource)
+
 	// 初始化服务
-	var err error
+	a.servicesMu.Lock()
+	if err := a.startServices(); err != nil {
+		runtime.LogErrorf(ctx, "Failed to initialize word service: %v", err)
+	} else {
+		a.importLegacyWords()
+	}
+	a.servicesMu.Unlock()
+	go a.runAutoBackup(ctx)
+
+	runtime.LogInfo(ctx, "WordMaster application started")
+}
 
+// startServices 在当前数据目录中初始化各个服务，返回单词服务的初始化错误
+// 调用方需持有 servicesMu 写锁
+func (a *App) startServices() error {
 	// 初始化单词服务
-	a.wordService, err = services.NewWordService(a.dataDir)
-	if err != nil {
-		runtime.LogErrorf(ctx, "Failed to initialize word service: %v", err)
+	wordService, wordErr := services.NewWordService(a.dataDir)
+	if wordErr == nil {
+		a.wordService = wordService
 	}
 
 	// 初始化音频服务
-	a.audioService, err = services.NewAudioService(a.audioDir)
+	audioService, err := services.NewAudioService(a.audioDir)
 	if err != nil {
-		runtime.LogErrorf(ctx, "Failed to initialize audio service: %v", err)
+		runtime.LogErrorf(a.ctx, "Failed to initialize audio service: %v", err)
+	} else {
+		audioService.SetContext(a.ctx) // 设置音频服务的上下文
+		a.audioService = audioService
 	}
-	a.audioService.SetContext(ctx) // 设置音频服务的上下文
 
 	// 初始化图片服务
-	a.imageService, err = services.NewImageService(a.imageDir)
+	imageService, err := services.NewImageService(a.imageDir)
 	if err != nil {
-		runtime.LogErrorf(ctx, "Failed to initialize image service: %v", err)
+		runtime.LogErrorf(a.ctx, "Failed to initialize image service: %v", err)
+	} else {
+		a.imageService = imageService
 	}
 
-	runtime.LogInfo(ctx, "WordMaster application started")
+	return wordErr
+}
+
+// importLegacyWords 将早期版本的 words.json 迁移到数据库
+func (a *App) importLegacyWords() {
+	result, err := a.wordService.ImportLegacyWords()
+	if err != nil {
+		runtime.LogErrorf(a.ctx, "Failed to import legacy words.json: %v", err)
+		return
+	}
+	if result != nil {
+		runtime.LogInfof(a.ctx, "Imported legacy words.json: %d added, %d existing, archived to %s",
+			result.Added, result.Existing, result.Archive)
+		a.legacyImport = result
+	}
+}
+
+// domReady 页面加载完成后通知前端启动时迁移旧数据的结果
+func (a *App) domReady(ctx context.Context) {
+	a.servicesMu.Lock()
+	defer a.servicesMu.Unlock()
+	if a.legacyImport != nil {
+		runtime.EventsEmit(ctx, "legacy-import", a.legacyImport)
+		a.legacyImport = nil
+	}
+}
+
+// runAutoBackup 启动时及之后每小时检查一次，距离上次自动备份超过一天时备份数据库
+func (a *App) runAutoBackup(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		// 移动数据目录后单词服务会被替换，每次检查时重新获取
+		a.servicesMu.RLock()
+		if a.wordService != nil {
+			if created, err := a.wordService.AutoBackup(); err != nil {
+				runtime.LogErrorf(ctx, "Automatic backup failed: %v", err)
+			} else if created {
+				runtime.LogInfo(ctx, "Database backed up automatically")
+			}
+		}
+		a.servicesMu.RUnlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 // GetAllWords 获取所有单词
 func (a *App) GetAllWords() []models.Word {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		runtime.LogError(a.ctx, "Word service not initialized")
 		return []models.Word{}
@@ -103,6 +189,8 @@ func (a *App) GetAllWords() []models.Word {
 
 // GetWordByID 根据ID获取单词
 func (a *App) GetWordByID(id int) (models.Word, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		return models.Word{}, fmt.Errorf("word service not initialized")
 	}
@@ -111,81 +199,631 @@ func (a *App) GetWordByID(id int) (models.Word, error) {
 
 // AddWord 添加新单词
 func (a *App) AddWord(word models.Word) (models.Word, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		return models.Word{}, fmt.Errorf("word service not initialized")
 	}
 	return a.wordService.AddWord(word)
 }
 
+// LookupWord 按单词的任意形式查找词库中的单词，如 ran 找到 run，找不到时返回ID为0的空单词
+func (a *App) LookupWord(form string) (models.Word, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.Word{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.LookupWord(form)
+}
+
 // UpdateWord 更新单词
 func (a *App) UpdateWord(word models.Word) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		return fmt.Errorf("word service not initialized")
 	}
 	return a.wordService.UpdateWord(word)
 }
 
-// DeleteWord 删除单词
+// DeleteWord 将单词移入回收站
 func (a *App) DeleteWord(id int) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		return fmt.Errorf("word service not initialized")
 	}
 	return a.wordService.DeleteWord(id)
 }
 
-// GetWordsForReview 获取今天需要复习的单词
-func (a *App) GetWordsForReview() []models.Word {
+// GetWordRevisions 获取单词的修订历史，最近的排在前面
+func (a *App) GetWordRevisions(wordID int) []models.WordRevision {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.WordRevision{}
+	}
+	return a.wordService.GetWordRevisions(wordID)
+}
+
+// RevertWordRevision 将单词内容恢复到某次修订之后的状态
+func (a *App) RevertWordRevision(revisionID int) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.RevertWordRevision(revisionID)
+}
+
+// ListTrash 获取回收站中的单词
+func (a *App) ListTrash() []models.TrashedWord {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.TrashedWord{}
+	}
+	return a.wordService.ListTrash()
+}
+
+// RestoreWord 将单词从回收站恢复
+func (a *App) RestoreWord(id int) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.RestoreWord(id)
+}
+
+// PurgeTrash 彻底删除回收站中超过 olderThanDays 天的单词，0表示清空回收站，返回删除的单词数
+func (a *App) PurgeTrash(olderThanDays int) (int, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return 0, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.PurgeTrash(olderThanDays)
+}
+
+// ListBackups 获取数据库备份列表
+func (a *App) ListBackups() ([]models.Backup, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return []models.Backup{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.ListBackups()
+}
+
+// CreateBackup 立即备份数据库
+func (a *App) CreateBackup() (models.Backup, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.Backup{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.CreateBackup()
+}
+
+// RestoreBackup 用备份替换当前数据库，恢复前会先备份当前数据库
+// 恢复期间数据库会关闭，持有写锁使其他调用等待恢复完成
+func (a *App) RestoreBackup(name string) error {
+	a.servicesMu.Lock()
+	defer a.servicesMu.Unlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	err := a.wordService.RestoreBackup(name)
+	if err != nil && a.wordService.Closed() {
+		// 回滚后也无法重新打开数据库，丢弃服务使其他绑定方法返回未初始化错误
+		runtime.LogErrorf(a.ctx, "Word service closed after failed restore: %v", err)
+		a.wordService = nil
+	}
+	return err
+}
+
+// GetDataDirectory 获取当前数据目录及其来源
+func (a *App) GetDataDirectory() models.DataDirectory {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	return models.DataDirectory{
+		Path:    a.dataDir,
+		Source:  a.dataSource,
+		Movable: a.configPath != "",
+	}
+}
+
+// MoveDataDirectory 将数据库、音频、图片和备份移动到 target 目录，并记住新的数据目录
+// 先复制再删除原目录中的数据，任何一步失败都继续使用原来的数据目录
+func (a *App) MoveDataDirectory(target string) error {
+	a.servicesMu.Lock()
+	defer a.servicesMu.Unlock()
+	if a.configPath == "" {
+		return fmt.Errorf("data directory is set by %s and cannot be moved", a.dataSource)
+	}
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+	if isSubpath(a.dataDir, target) || isSubpath(target, a.dataDir) {
+		return fmt.Errorf("'%s' overlaps the current data directory", target)
+	}
+
+	// 复制前关闭数据库，保证复制的是完整的数据库文件
+	if wordService := a.wordService; wordService != nil {
+		a.wordService = nil
+		if err := wordService.Close(); err != nil {
+			a.startServices()
+			return err
+		}
+	}
+	if err := services.CopyDataDirectory(a.dataDir, target); err != nil {
+		a.startServices()
+		return err
+	}
+
+	// 记住原来的配置，新目录无法使用时恢复
+	oldDir := a.dataDir
+	configData, configErr := os.ReadFile(a.configPath)
+	if configErr != nil && !errors.Is(configErr, os.ErrNotExist) {
+		services.RemoveDataDirectory(target)
+		a.startServices()
+		return configErr
+	}
+	restoreConfig := func() {
+		if configErr != nil {
+			os.Remove(a.configPath)
+		} else {
+			os.WriteFile(a.configPath, configData, 0644)
+		}
+	}
+	if err := saveDataDir(a.configPath, target); err != nil {
+		restoreConfig()
+		services.RemoveDataDirectory(target)
+		a.startServices()
+		return fmt.Errorf("failed to save data directory: %w", err)
+	}
+
+	a.setDataDir(target)
+	if err := a.startServices(); err != nil {
+		restoreConfig()
+		a.setDataDir(oldDir)
+		a.startServices()
+		services.RemoveDataDirectory(target)
+		return fmt.Errorf("failed to open moved data: %w", err)
+	}
+	if a.dataSource == dataDirSourceDefault {
+		a.dataSource = dataDirSourceConfig
+	}
+
+	if err := services.RemoveDataDirectory(oldDir); err != nil {
+		runtime.LogWarningf(a.ctx, "Failed to remove old data directory %s: %v", oldDir, err)
+	}
+	runtime.LogInfof(a.ctx, "Data directory moved to %s", target)
+	return nil
+}
+
+// isSubpath 判断 path 是否为 dir 或在 dir 之下
+func isSubpath(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && (rel == "." || filepath.IsLocal(rel))
+}
+
+// GetWordsForReview 获取今天需要复习的单词(按复习排序和每日复习上限)，deckID 为0表示所有单词本
+func (a *App) GetWordsForReview(deckID int) []models.Word {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		runtime.LogError(a.ctx, "Word service not initialized")
 		return []models.Word{}
 	}
-	return a.wordService.GetWordsForReview()
+	return a.wordService.GetWordsForReview(deckID)
 }
 
-// GetNewWordsToLearn 获取新的待学习单词
-func (a *App) GetNewWordsToLearn(count int) []models.Word {
+// GetNewWordsToLearn 获取新的待学习单词(受每日新词上限限制)，deckID 为0表示所有单词本
+func (a *App) GetNewWordsToLearn(count int, deckID int) []models.Word {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		runtime.LogError(a.ctx, "Word service not initialized")
 		return []models.Word{}
 	}
-	return a.wordService.GetNewWordsToLearn(count)
+	return a.wordService.GetNewWordsToLearn(count, deckID)
+}
+
+// GetStudyQueue 获取今日学习队列(含每日上限与新词混合)，deckID 为0表示所有单词本
+func (a *App) GetStudyQueue(deckID int) (models.StudyQueue, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.StudyQueue{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.GetStudyQueue(deckID), nil
 }
 
 // UpdateWordAfterReview 根据用户反馈更新单词的间隔重复参数
 func (a *App) UpdateWordAfterReview(id int, quality int) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		return fmt.Errorf("word service not initialized")
 	}
 	return a.wordService.UpdateWordAfterReview(id, quality)
 }
 
-// ImportWords 从JSON文件导入单词
-func (a *App) ImportWords(filePath string) error {
+// ReviewWord 更新单词复习状态并记录作答用时(毫秒)
+func (a *App) ReviewWord(id int, quality int, responseTime int64) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		return fmt.Errorf("word service not initialized")
 	}
-	return a.wordService.ImportWords(filePath)
+	return a.wordService.ReviewWord(id, quality, responseTime)
 }
 
-// ExportWords 导出单词到JSON文件
-func (a *App) ExportWords(filePath string) error {
+// ReviewCard 更新卡片复习状态并记录作答用时(毫秒)
+func (a *App) ReviewCard(cardID int, quality int, responseTime int64) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		return fmt.Errorf("word service not initialized")
 	}
-	return a.wordService.ExportWords(filePath)
+	return a.wordService.ReviewCard(cardID, quality, responseTime)
+}
+
+// GetWordCards 获取单词的所有卡片
+func (a *App) GetWordCards(wordID int) []models.Card {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.Card{}
+	}
+	return a.wordService.GetWordCards(wordID)
 }
 
-// GetLearningStats 获取学习统计信息
-func (a *App) GetLearningStats() map[string]int {
+// GetReviewLogs 获取单词的复习记录
+func (a *App) GetReviewLogs(wordID int) []models.ReviewLog {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.ReviewLog{}
+	}
+	return a.wordService.GetReviewLogs(wordID)
+}
+
+// ImportWords 从JSON文件导入单词，deckID 不为0时加入该单词本，mergeForms 控制是否将变形合并到原形
+func (a *App) ImportWords(filePath string, deckID int, mergeForms bool) (models.ImportResult, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.ImportResult{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.ImportWords(filePath, deckID, mergeForms)
+}
+
+// PreviewCSV 预览CSV/TSV文件，返回建议的列映射，delimiter 和 encoding 为空时自动检测
+func (a *App) PreviewCSV(filePath, delimiter, encoding string) (models.CSVPreview, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.CSVPreview{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.PreviewCSV(filePath, delimiter, encoding)
+}
+
+// ImportCSV 按列映射从CSV/TSV文件导入单词
+func (a *App) ImportCSV(filePath string, options models.CSVImportOptions) (models.ImportResult, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.ImportResult{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.ImportCSV(filePath, options)
+}
+
+// ExportWords 导出单词到JSON文件，includeProgress 控制是否包含学习进度
+func (a *App) ExportWords(filePath string, includeProgress bool) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.ExportWords(filePath, includeProgress)
+}
+
+// GetLearningStats 获取学习统计信息，deckID 为0表示所有单词本
+func (a *App) GetLearningStats(deckID int) map[string]int {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.wordService == nil {
 		runtime.LogError(a.ctx, "Word service not initialized")
 		return map[string]int{}
 	}
-	return a.wordService.GetLearningStats()
+	return a.wordService.GetLearningStats(deckID)
+}
+
+// GetDecks 获取所有单词本
+func (a *App) GetDecks() []models.Deck {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.Deck{}
+	}
+	return a.wordService.GetDecks()
+}
+
+// CreateDeck 创建单词本
+func (a *App) CreateDeck(deck models.Deck) (models.Deck, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.Deck{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.CreateDeck(deck)
+}
+
+// UpdateDeck 更新单词本
+func (a *App) UpdateDeck(deck models.Deck) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.UpdateDeck(deck)
+}
+
+// DeleteDeck 删除单词本(不删除其中的单词)
+func (a *App) DeleteDeck(id int) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.DeleteDeck(id)
+}
+
+// AddWordsToDeck 将单词加入单词本
+func (a *App) AddWordsToDeck(deckID int, wordIDs []int) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.AddWordsToDeck(deckID, wordIDs)
+}
+
+// RemoveWordsFromDeck 将单词移出单词本
+func (a *App) RemoveWordsFromDeck(deckID int, wordIDs []int) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.RemoveWordsFromDeck(deckID, wordIDs)
+}
+
+// QueryWords 按查询语句筛选单词，如 "tag:verb -tag:easy deck:ielts due:today"
+func (a *App) QueryWords(query string) ([]models.Word, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return []models.Word{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.QueryWords(query)
+}
+
+// ListWords 分页获取单词列表，支持排序和筛选
+func (a *App) ListWords(params models.WordListParams) (models.WordPage, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.WordPage{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.ListWords(params)
+}
+
+// SearchWords 全文搜索单词、释义和例句，返回按相关度排序的高亮结果
+func (a *App) SearchWords(query string, limit int, offset int) ([]models.SearchResult, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return []models.SearchResult{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.SearchWords(query, limit, offset)
+}
+
+// AddWordRelation 添加单词关系，如近义词、反义词、派生、易混词和搭配
+func (a *App) AddWordRelation(wordID int, relatedID int, relationType string) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.AddWordRelation(wordID, relatedID, relationType)
+}
+
+// RemoveWordRelation 删除单词关系
+func (a *App) RemoveWordRelation(wordID int, relatedID int, relationType string) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.RemoveWordRelation(wordID, relatedID, relationType)
+}
+
+// GetRelatedWords 获取单词的关联单词
+func (a *App) GetRelatedWords(wordID int) []models.RelatedWord {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.RelatedWord{}
+	}
+	return a.wordService.GetRelatedWords(wordID)
+}
+
+// GetTags 获取所有标签
+func (a *App) GetTags() []models.Tag {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.Tag{}
+	}
+	return a.wordService.GetTags()
+}
+
+// TagWords 为单词批量添加标签
+func (a *App) TagWords(wordIDs []int, tags []string) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.TagWords(wordIDs, tags)
+}
+
+// UntagWords 批量移除单词的标签
+func (a *App) UntagWords(wordIDs []int, tags []string) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.UntagWords(wordIDs, tags)
+}
+
+// GetProfiles 获取所有学习者
+func (a *App) GetProfiles() []models.Profile {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.Profile{}
+	}
+	return a.wordService.GetProfiles()
+}
+
+// GetActiveProfile 获取当前学习者
+func (a *App) GetActiveProfile() (models.Profile, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.Profile{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.GetActiveProfile()
+}
+
+// CreateProfile 创建学习者
+func (a *App) CreateProfile(name string) (models.Profile, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.Profile{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.CreateProfile(name)
+}
+
+// SwitchProfile 切换当前学习者
+func (a *App) SwitchProfile(id int) error {
+	// 会修改服务的当前学习者和学习设置，其他绑定方法同时在读取
+	a.servicesMu.Lock()
+	defer a.servicesMu.Unlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.SwitchProfile(id)
+}
+
+// DeleteProfile 删除学习者及其学习进度
+func (a *App) DeleteProfile(id int) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.DeleteProfile(id)
+}
+
+// GetLeeches 获取顽固词卡片列表
+func (a *App) GetLeeches() []models.Card {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		runtime.LogError(a.ctx, "Word service not initialized")
+		return []models.Card{}
+	}
+	return a.wordService.GetLeeches()
+}
+
+// SetWordSuspended 暂停或恢复单词的学习
+func (a *App) SetWordSuspended(id int, suspended bool) error {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.SetWordSuspended(id, suspended)
+}
+
+// GetReviewForecast 预测未来days天每天的复习量
+func (a *App) GetReviewForecast(days int) ([]models.ForecastDay, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return nil, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.GetReviewForecast(days)
+}
+
+// SimulateSchedule 模拟调整每日新词数或目标保持率后的复习量
+func (a *App) SimulateSchedule(params models.SimulationParams) (models.SimulationResult, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.SimulationResult{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.Simulate(params)
+}
+
+// GetStudySettings 获取学习设置
+func (a *App) GetStudySettings() (models.StudySettings, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	if a.wordService == nil {
+		return models.StudySettings{}, fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.GetStudySettings(), nil
+}
+
+// UpdateStudySettings 更新学习设置(包括调度算法)
+func (a *App) UpdateStudySettings(settings models.StudySettings) error {
+	// 会修改服务的当前学习者和学习设置，其他绑定方法同时在读取
+	a.servicesMu.Lock()
+	defer a.servicesMu.Unlock()
+	if a.wordService == nil {
+		return fmt.Errorf("word service not initialized")
+	}
+	return a.wordService.UpdateStudySettings(settings)
+}
+
+// GetAvailableSchedulers 获取可选的调度算法
+func (a *App) GetAvailableSchedulers() []string {
+	return services.AvailableSchedulers()
 }
 
 // GetPronunciation 获取单词发音
 func (a *App) GetPronunciation(word string) (string, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.audioService == nil {
 		return "", fmt.Errorf("audio service not initialized")
 	}
@@ -194,6 +832,8 @@ func (a *App) GetPronunciation(word string) (string, error) {
 
 // GetWordImage 获取单词图片
 func (a *App) GetWordImage(word string) (string, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.imageService == nil {
 		return "", fmt.Errorf("image service not initialized")
 	}
@@ -202,6 +842,8 @@ func (a *App) GetWordImage(word string) (string, error) {
 
 // SaveWordImageFromURL 从URL保存单词图片
 func (a *App) SaveWordImageFromURL(word string, imageURL string) (string, error) {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
 	if a.imageService == nil {
 		return "", fmt.Errorf("image service not initialized")
 	}
@@ -218,6 +860,14 @@ func (a *App) OpenFileDialog(title string, filters map[string][]string) (string,
 	})
 }
 
+// OpenDirectoryDialog 打开目录选择对话框
+func (a *App) OpenDirectoryDialog(title string) (string, error) {
+	return runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:                title,
+		CanCreateDirectories: true,
+	})
+}
+
 // SaveFileDialog 打开文件保存对话框
 func (a *App) SaveFileDialog(title string, defaultFilename string, filters map[string][]string) (string, error) {
 	// 将map[string][]string转换为[]frontend.FileFilter
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// dataDirEnv 指定数据目录的环境变量
+const dataDirEnv = "WORDMASTER_DATA_DIR"
+
+// portableConfigName 便携模式的配置文件名，可执行文件旁存在该文件时使用便携模式
+const portableConfigName = "wordmaster.json"
+
+// portableDataDirName 便携模式下默认的数据目录名，位于可执行文件旁
+const portableDataDirName = "data"
+
+// 数据目录的来源
+const (
+	dataDirSourceFlag     = "flag"     // 命令行参数 --data-dir
+	dataDirSourceEnv      = "env"      // 环境变量 WORDMASTER_DATA_DIR
+	dataDirSourcePortable = "portable" // 便携模式，数据保存在可执行文件旁
+	dataDirSourceConfig   = "config"   // 用户配置目录中的配置文件
+	dataDirSourceDefault  = "default"  // 默认的 ~/.wordmaster
+)
+
+// appConfig 配置文件内容
+type appConfig struct {
+	DataDir string `json:"dataDir,omitempty"` // 数据目录，相对路径相对于配置文件所在目录
+}
+
+// dataLocation 数据目录及其来源
+type dataLocation struct {
+	dir        string // 数据目录的绝对路径
+	source     string // 数据目录的来源
+	configPath string // 修改数据目录时写入的配置文件，命令行参数和环境变量指定时为空
+}
+
+// parseFlags 解析命令行参数 --data-dir 和 --portable
+// 跳过无法识别的参数(如系统启动程序时附加的 -psn_ 参数)，因此不使用 flag 包
+func parseFlags(args []string) (dataDir string, portable bool) {
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		switch name {
+		case "data-dir":
+			if !hasValue && i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+			dataDir = value
+		case "portable":
+			portable = true
+			if hasValue {
+				portable, _ = strconv.ParseBool(value)
+			}
+		}
+	}
+	return dataDir, portable
+}
+
+// resolveDataDir 确定数据目录，优先级依次为命令行参数、环境变量、便携模式、配置文件和默认目录
+// 便携模式由 --portable 参数或可执行文件旁的 wordmaster.json 开启
+func resolveDataDir(args []string) (dataLocation, error) {
+	dataDir, portable := parseFlags(args)
+	if dataDir != "" {
+		dir, err := filepath.Abs(dataDir)
+		return dataLocation{dir: dir, source: dataDirSourceFlag}, err
+	}
+	if dataDir := os.Getenv(dataDirEnv); dataDir != "" {
+		dir, err := filepath.Abs(dataDir)
+		return dataLocation{dir: dir, source: dataDirSourceEnv}, err
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return dataLocation{}, err
+	}
+	portableConfig := filepath.Join(filepath.Dir(exePath), portableConfigName)
+	if _, err := os.Stat(portableConfig); err == nil {
+		portable = true
+	}
+	if portable {
+		return locationFromConfig(portableConfig, dataDirSourcePortable,
+			filepath.Join(filepath.Dir(exePath), portableDataDirName))
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return dataLocation{}, err
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return dataLocation{}, err
+	}
+	location, err := locationFromConfig(filepath.Join(configDir, "WordMaster", "config.json"),
+		dataDirSourceConfig, filepath.Join(homeDir, ".wordmaster"))
+	if err == nil && location.dir == filepath.Join(homeDir, ".wordmaster") {
+		location.source = dataDirSourceDefault
+	}
+	return location, err
+}
+
+// locationFromConfig 读取配置文件中的数据目录，配置文件不存在或没有指定时使用 defaultDir
+func locationFromConfig(configPath, source, defaultDir string) (dataLocation, error) {
+	location := dataLocation{dir: defaultDir, source: source, configPath: configPath}
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return dataLocation{}, err
+	}
+	if config.DataDir != "" {
+		location.dir = config.DataDir
+		if !filepath.IsAbs(location.dir) {
+			location.dir = filepath.Join(filepath.Dir(configPath), location.dir)
+		}
+	}
+	return location, nil
+}
+
+// loadConfig 读取配置文件，文件不存在时返回空配置
+func loadConfig(configPath string) (appConfig, error) {
+	var config appConfig
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	// 便携模式的配置文件可以是空文件
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return config, nil
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// saveDataDir 将数据目录写入配置文件，数据目录在配置文件所在目录下时保存为相对路径
+// 便携模式下整个目录被移动到其他位置后仍能找到数据
+func saveDataDir(configPath, dataDir string) error {
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	config.DataDir = dataDir
+	if rel, err := filepath.Rel(filepath.Dir(configPath), dataDir); err == nil && filepath.IsLocal(rel) {
+		config.DataDir = rel
+	}
+	return saveConfig(configPath, config)
+}
+
+// saveConfig 写入配置文件
+func saveConfig(configPath string, config appConfig) error {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configPath, data, 0644)
+}
@@ -4,6 +4,8 @@ import LearnPage from '../components/LearnPage.vue';
 import ReviewPage from '../components/ReviewPage.vue';
 import WordsPage from '../components/WordsPage.vue';
 import ImportPage from '../components/ImportPage.vue';
+import TrashPage from '../components/TrashPage.vue';
+import BackupsPage from '../components/BackupsPage.vue';
 
 const routes = [
   {
@@ -30,6 +32,16 @@ const routes = [
     path: '/import',
     name: 'Import',
     component: ImportPage
+  },
+  {
+    path: '/trash',
+    name: 'Trash',
+    component: TrashPage
+  },
+  {
+    path: '/backups',
+    name: 'Backups',
+    component: BackupsPage
   }
 ];
 
@@ -4,32 +4,124 @@ import {models} from '../models';
 
 export function AddWord(arg1:models.Word):Promise<models.Word>;
 
+export function AddWordRelation(arg1:number,arg2:number,arg3:string):Promise<void>;
+
+export function AddWordsToDeck(arg1:number,arg2:Array<number>):Promise<void>;
+
+export function CreateBackup():Promise<models.Backup>;
+
+export function CreateDeck(arg1:models.Deck):Promise<models.Deck>;
+
+export function CreateProfile(arg1:string):Promise<models.Profile>;
+
+export function DeleteDeck(arg1:number):Promise<void>;
+
+export function DeleteProfile(arg1:number):Promise<void>;
+
 export function DeleteWord(arg1:number):Promise<void>;
 
-export function ExportWords(arg1:string):Promise<void>;
+export function ExportWords(arg1:string,arg2:boolean):Promise<void>;
+
+export function GetActiveProfile():Promise<models.Profile>;
 
 export function GetAllWords():Promise<Array<models.Word>>;
 
-export function GetLearningStats():Promise<Record<string, number>>;
+export function GetAvailableSchedulers():Promise<Array<string>>;
+
+export function GetDataDirectory():Promise<models.DataDirectory>;
+
+export function GetDecks():Promise<Array<models.Deck>>;
+
+export function GetLearningStats(arg1:number):Promise<Record<string, number>>;
+
+export function GetLeeches():Promise<Array<models.Card>>;
 
-export function GetNewWordsToLearn(arg1:number):Promise<Array<models.Word>>;
+export function GetNewWordsToLearn(arg1:number,arg2:number):Promise<Array<models.Word>>;
+
+export function GetProfiles():Promise<Array<models.Profile>>;
 
 export function GetPronunciation(arg1:string):Promise<string>;
 
+export function GetRelatedWords(arg1:number):Promise<Array<models.RelatedWord>>;
+
+export function GetReviewForecast(arg1:number):Promise<Array<models.ForecastDay>>;
+
+export function GetReviewLogs(arg1:number):Promise<Array<models.ReviewLog>>;
+
+export function GetStudyQueue(arg1:number):Promise<models.StudyQueue>;
+
+export function GetStudySettings():Promise<models.StudySettings>;
+
+export function GetTags():Promise<Array<models.Tag>>;
+
 export function GetWordByID(arg1:number):Promise<models.Word>;
 
+export function GetWordCards(arg1:number):Promise<Array<models.Card>>;
+
 export function GetWordImage(arg1:string):Promise<string>;
 
-export function GetWordsForReview():Promise<Array<models.Word>>;
+export function GetWordRevisions(arg1:number):Promise<Array<models.WordRevision>>;
+
+export function GetWordsForReview(arg1:number):Promise<Array<models.Word>>;
+
+export function ImportCSV(arg1:string,arg2:models.CSVImportOptions):Promise<models.ImportResult>;
+
+export function ImportWords(arg1:string,arg2:number,arg3:boolean):Promise<models.ImportResult>;
 
-export function ImportWords(arg1:string):Promise<void>;
+export function ListBackups():Promise<Array<models.Backup>>;
+
+export function ListTrash():Promise<Array<models.TrashedWord>>;
+
+export function ListWords(arg1:models.WordListParams):Promise<models.WordPage>;
+
+export function LookupWord(arg1:string):Promise<models.Word>;
+
+export function MoveDataDirectory(arg1:string):Promise<void>;
+
+export function OpenDirectoryDialog(arg1:string):Promise<string>;
 
 export function OpenFileDialog(arg1:string,arg2:Record<string, Array<string>>):Promise<string>;
 
+export function PreviewCSV(arg1:string,arg2:string,arg3:string):Promise<models.CSVPreview>;
+
+export function PurgeTrash(arg1:number):Promise<number>;
+
+export function QueryWords(arg1:string):Promise<Array<models.Word>>;
+
+export function RemoveWordRelation(arg1:number,arg2:number,arg3:string):Promise<void>;
+
+export function RemoveWordsFromDeck(arg1:number,arg2:Array<number>):Promise<void>;
+
+export function RestoreBackup(arg1:string):Promise<void>;
+
+export function RestoreWord(arg1:number):Promise<void>;
+
+export function RevertWordRevision(arg1:number):Promise<void>;
+
+export function ReviewCard(arg1:number,arg2:number,arg3:number):Promise<void>;
+
+export function ReviewWord(arg1:number,arg2:number,arg3:number):Promise<void>;
+
 export function SaveFileDialog(arg1:string,arg2:string,arg3:Record<string, Array<string>>):Promise<string>;
 
 export function SaveWordImageFromURL(arg1:string,arg2:string):Promise<string>;
 
+export function SearchWords(arg1:string,arg2:number,arg3:number):Promise<Array<models.SearchResult>>;
+
+export function SetWordSuspended(arg1:number,arg2:boolean):Promise<void>;
+
+export function SimulateSchedule(arg1:models.SimulationParams):Promise<models.SimulationResult>;
+
+export function SwitchProfile(arg1:number):Promise<void>;
+
+export function TagWords(arg1:Array<number>,arg2:Array<string>):Promise<void>;
+
+export function UntagWords(arg1:Array<number>,arg2:Array<string>):Promise<void>;
+
+export function UpdateDeck(arg1:models.Deck):Promise<void>;
+
+export function UpdateStudySettings(arg1:models.StudySettings):Promise<void>;
+
 export function UpdateWord(arg1:models.Word):Promise<void>;
 
 export function UpdateWordAfterReview(arg1:number,arg2:number):Promise<void>;
@@ -6,50 +6,202 @@ export function AddWord(arg1) {
   return window['go']['main']['App']['AddWord'](arg1);
 }
 
+export function AddWordRelation(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddWordRelation'](arg1, arg2, arg3);
+}
+
+export function AddWordsToDeck(arg1, arg2) {
+  return window['go']['main']['App']['AddWordsToDeck'](arg1, arg2);
+}
+
+export function CreateBackup() {
+  return window['go']['main']['App']['CreateBackup']();
+}
+
+export function CreateDeck(arg1) {
+  return window['go']['main']['App']['CreateDeck'](arg1);
+}
+
+export function CreateProfile(arg1) {
+  return window['go']['main']['App']['CreateProfile'](arg1);
+}
+
+export function DeleteDeck(arg1) {
+  return window['go']['main']['App']['DeleteDeck'](arg1);
+}
+
+export function DeleteProfile(arg1) {
+  return window['go']['main']['App']['DeleteProfile'](arg1);
+}
+
 export function DeleteWord(arg1) {
   return window['go']['main']['App']['DeleteWord'](arg1);
 }
 
-export function ExportWords(arg1) {
-  return window['go']['main']['App']['ExportWords'](arg1);
+export function ExportWords(arg1, arg2) {
+  return window['go']['main']['App']['ExportWords'](arg1, arg2);
+}
+
+export function GetActiveProfile() {
+  return window['go']['main']['App']['GetActiveProfile']();
 }
 
 export function GetAllWords() {
   return window['go']['main']['App']['GetAllWords']();
 }
 
-export function GetLearningStats() {
-  return window['go']['main']['App']['GetLearningStats']();
+export function GetAvailableSchedulers() {
+  return window['go']['main']['App']['GetAvailableSchedulers']();
+}
+
+export function GetDataDirectory() {
+  return window['go']['main']['App']['GetDataDirectory']();
+}
+
+export function GetDecks() {
+  return window['go']['main']['App']['GetDecks']();
+}
+
+export function GetLearningStats(arg1) {
+  return window['go']['main']['App']['GetLearningStats'](arg1);
+}
+
+export function GetLeeches() {
+  return window['go']['main']['App']['GetLeeches']();
+}
+
+export function GetNewWordsToLearn(arg1, arg2) {
+  return window['go']['main']['App']['GetNewWordsToLearn'](arg1, arg2);
 }
 
-export function GetNewWordsToLearn(arg1) {
-  return window['go']['main']['App']['GetNewWordsToLearn'](arg1);
+export function GetProfiles() {
+  return window['go']['main']['App']['GetProfiles']();
 }
 
 export function GetPronunciation(arg1) {
   return window['go']['main']['App']['GetPronunciation'](arg1);
 }
 
+export function GetRelatedWords(arg1) {
+  return window['go']['main']['App']['GetRelatedWords'](arg1);
+}
+
+export function GetReviewForecast(arg1) {
+  return window['go']['main']['App']['GetReviewForecast'](arg1);
+}
+
+export function GetReviewLogs(arg1) {
+  return window['go']['main']['App']['GetReviewLogs'](arg1);
+}
+
+export function GetStudyQueue(arg1) {
+  return window['go']['main']['App']['GetStudyQueue'](arg1);
+}
+
+export function GetStudySettings() {
+  return window['go']['main']['App']['GetStudySettings']();
+}
+
+export function GetTags() {
+  return window['go']['main']['App']['GetTags']();
+}
+
 export function GetWordByID(arg1) {
   return window['go']['main']['App']['GetWordByID'](arg1);
 }
 
+export function GetWordCards(arg1) {
+  return window['go']['main']['App']['GetWordCards'](arg1);
+}
+
 export function GetWordImage(arg1) {
   return window['go']['main']['App']['GetWordImage'](arg1);
 }
 
-export function GetWordsForReview() {
-  return window['go']['main']['App']['GetWordsForReview']();
+export function GetWordRevisions(arg1) {
+  return window['go']['main']['App']['GetWordRevisions'](arg1);
+}
+
+export function GetWordsForReview(arg1) {
+  return window['go']['main']['App']['GetWordsForReview'](arg1);
+}
+
+export function ImportCSV(arg1, arg2) {
+  return window['go']['main']['App']['ImportCSV'](arg1, arg2);
+}
+
+export function ImportWords(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportWords'](arg1, arg2, arg3);
 }
 
-export function ImportWords(arg1) {
-  return window['go']['main']['App']['ImportWords'](arg1);
+export function ListBackups() {
+  return window['go']['main']['App']['ListBackups']();
+}
+
+export function ListTrash() {
+  return window['go']['main']['App']['ListTrash']();
+}
+
+export function ListWords(arg1) {
+  return window['go']['main']['App']['ListWords'](arg1);
+}
+
+export function LookupWord(arg1) {
+  return window['go']['main']['App']['LookupWord'](arg1);
+}
+
+export function MoveDataDirectory(arg1) {
+  return window['go']['main']['App']['MoveDataDirectory'](arg1);
+}
+
+export function OpenDirectoryDialog(arg1) {
+  return window['go']['main']['App']['OpenDirectoryDialog'](arg1);
 }
 
 export function OpenFileDialog(arg1, arg2) {
   return window['go']['main']['App']['OpenFileDialog'](arg1, arg2);
 }
 
+export function PreviewCSV(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewCSV'](arg1, arg2, arg3);
+}
+
+export function PurgeTrash(arg1) {
+  return window['go']['main']['App']['PurgeTrash'](arg1);
+}
+
+export function QueryWords(arg1) {
+  return window['go']['main']['App']['QueryWords'](arg1);
+}
+
+export function RemoveWordRelation(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RemoveWordRelation'](arg1, arg2, arg3);
+}
+
+export function RemoveWordsFromDeck(arg1, arg2) {
+  return window['go']['main']['App']['RemoveWordsFromDeck'](arg1, arg2);
+}
+
+export function RestoreBackup(arg1) {
+  return window['go']['main']['App']['RestoreBackup'](arg1);
+}
+
+export function RestoreWord(arg1) {
+  return window['go']['main']['App']['RestoreWord'](arg1);
+}
+
+export function RevertWordRevision(arg1) {
+  return window['go']['main']['App']['RevertWordRevision'](arg1);
+}
+
+export function ReviewCard(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ReviewCard'](arg1, arg2, arg3);
+}
+
+export function ReviewWord(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ReviewWord'](arg1, arg2, arg3);
+}
+
 export function SaveFileDialog(arg1, arg2, arg3) {
   return window['go']['main']['App']['SaveFileDialog'](arg1, arg2, arg3);
 }
@@ -58,6 +210,38 @@ export function SaveWordImageFromURL(arg1, arg2) {
   return window['go']['main']['App']['SaveWordImageFromURL'](arg1, arg2);
 }
 
+export function SearchWords(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SearchWords'](arg1, arg2, arg3);
+}
+
+export function SetWordSuspended(arg1, arg2) {
+  return window['go']['main']['App']['SetWordSuspended'](arg1, arg2);
+}
+
+export function SimulateSchedule(arg1) {
+  return window['go']['main']['App']['SimulateSchedule'](arg1);
+}
+
+export function SwitchProfile(arg1) {
+  return window['go']['main']['App']['SwitchProfile'](arg1);
+}
+
+export function TagWords(arg1, arg2) {
+  return window['go']['main']['App']['TagWords'](arg1, arg2);
+}
+
+export function UntagWords(arg1, arg2) {
+  return window['go']['main']['App']['UntagWords'](arg1, arg2);
+}
+
+export function UpdateDeck(arg1) {
+  return window['go']['main']['App']['UpdateDeck'](arg1);
+}
+
+export function UpdateStudySettings(arg1) {
+  return window['go']['main']['App']['UpdateStudySettings'](arg1);
+}
+
 export function UpdateWord(arg1) {
   return window['go']['main']['App']['UpdateWord'](arg1);
 }
@@ -1,5 +1,219 @@
+export namespace gorm {
+	
+	export class DeletedAt {
+	    // Go type: time
+	    Time: any;
+	    Valid: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeletedAt(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Time = this.convertValues(source["Time"], null);
+	        this.Valid = source["Valid"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace models {
 	
+	export class Backup {
+	    name: string;
+	    reason: string;
+	    size: number;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Backup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.reason = source["reason"];
+	        this.size = source["size"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class CSVImportOptions {
+	    delimiter: string;
+	    encoding: string;
+	    hasHeader: boolean;
+	    columns: string[];
+	    deckId: number;
+	    mergeForms: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVImportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.delimiter = source["delimiter"];
+	        this.encoding = source["encoding"];
+	        this.hasHeader = source["hasHeader"];
+	        this.columns = source["columns"];
+	        this.deckId = source["deckId"];
+	        this.mergeForms = source["mergeForms"];
+	    }
+	}
+	export class CSVPreview {
+	    delimiter: string;
+	    encoding: string;
+	    hasHeader: boolean;
+	    rows: string[][];
+	    columns: string[];
+	    fields: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.delimiter = source["delimiter"];
+	        this.encoding = source["encoding"];
+	        this.hasHeader = source["hasHeader"];
+	        this.rows = source["rows"];
+	        this.columns = source["columns"];
+	        this.fields = source["fields"];
+	    }
+	}
+	export class Tag {
+	    id: number;
+	    name: string;
+	    wordCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Tag(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.wordCount = source["wordCount"];
+	    }
+	}
+	export class Deck {
+	    id: number;
+	    name: string;
+	    description: string;
+	    scheduler: string;
+	    createdAt: number;
+	    wordCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Deck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.scheduler = source["scheduler"];
+	        this.createdAt = source["createdAt"];
+	        this.wordCount = source["wordCount"];
+	    }
+	}
+	export class RelatedWord {
+	    id?: number;
+	    word: string;
+	    type: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RelatedWord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.word = source["word"];
+	        this.type = source["type"];
+	    }
+	}
+	export class SenseExample {
+	    id: number;
+	    senseId: number;
+	    ord: number;
+	    text: string;
+	    translation: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SenseExample(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.senseId = source["senseId"];
+	        this.ord = source["ord"];
+	        this.text = source["text"];
+	        this.translation = source["translation"];
+	    }
+	}
+	export class Sense {
+	    id: number;
+	    wordId: number;
+	    ord: number;
+	    pos: string;
+	    definition: string;
+	    examples?: SenseExample[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Sense(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.wordId = source["wordId"];
+	        this.ord = source["ord"];
+	        this.pos = source["pos"];
+	        this.definition = source["definition"];
+	        this.examples = this.convertValues(source["examples"], SenseExample);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Word {
 	    id: number;
 	    word: string;
@@ -10,13 +224,14 @@ export namespace models {
 	    translation: string;
 	    imageUrl: string;
 	    difficulty: number;
-	    lastReviewed: number;
-	    nextReview: number;
-	    reviewCount: number;
-	    easeFactor: number;
-	    interval: number;
+	    createdAt: number;
 	    learned: boolean;
 	    mastered: boolean;
+	    senses?: Sense[];
+	    related?: RelatedWord[];
+	    cards?: Card[];
+	    decks?: Deck[];
+	    tags?: Tag[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Word(source);
@@ -33,15 +248,605 @@ export namespace models {
 	        this.translation = source["translation"];
 	        this.imageUrl = source["imageUrl"];
 	        this.difficulty = source["difficulty"];
+	        this.createdAt = source["createdAt"];
+	        this.learned = source["learned"];
+	        this.mastered = source["mastered"];
+	        this.senses = this.convertValues(source["senses"], Sense);
+	        this.related = this.convertValues(source["related"], RelatedWord);
+	        this.cards = this.convertValues(source["cards"], Card);
+	        this.decks = this.convertValues(source["decks"], Deck);
+	        this.tags = this.convertValues(source["tags"], Tag);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Card {
+	    id: number;
+	    profileId: number;
+	    wordId: number;
+	    cardType: string;
+	    ord: number;
+	    state: string;
+	    step: number;
+	    lastReviewed: number;
+	    nextReview: number;
+	    reviewCount: number;
+	    easeFactor: number;
+	    interval: number;
+	    stability: number;
+	    memoryDifficulty: number;
+	    lapses: number;
+	    leech: boolean;
+	    suspended: boolean;
+	    buriedUntil: number;
+	    cloze: string;
+	    word?: Word;
+	
+	    static createFrom(source: any = {}) {
+	        return new Card(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.profileId = source["profileId"];
+	        this.wordId = source["wordId"];
+	        this.cardType = source["cardType"];
+	        this.ord = source["ord"];
+	        this.state = source["state"];
+	        this.step = source["step"];
 	        this.lastReviewed = source["lastReviewed"];
 	        this.nextReview = source["nextReview"];
 	        this.reviewCount = source["reviewCount"];
 	        this.easeFactor = source["easeFactor"];
 	        this.interval = source["interval"];
+	        this.stability = source["stability"];
+	        this.memoryDifficulty = source["memoryDifficulty"];
+	        this.lapses = source["lapses"];
+	        this.leech = source["leech"];
+	        this.suspended = source["suspended"];
+	        this.buriedUntil = source["buriedUntil"];
+	        this.cloze = source["cloze"];
+	        this.word = this.convertValues(source["word"], Word);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DataDirectory {
+	    path: string;
+	    source: string;
+	    movable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DataDirectory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.source = source["source"];
+	        this.movable = source["movable"];
+	    }
+	}
+	
+	export class FieldChange {
+	    field: string;
+	    old: string;
+	    new: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FieldChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.old = source["old"];
+	        this.new = source["new"];
+	    }
+	}
+	export class ForecastDay {
+	    date: string;
+	    reviews: number;
+	    newWords: number;
+	    lapses: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ForecastDay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.reviews = source["reviews"];
+	        this.newWords = source["newWords"];
+	        this.lapses = source["lapses"];
+	    }
+	}
+	export class FormMatch {
+	    word: string;
+	    lemma: string;
+	    merged: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FormMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = source["word"];
+	        this.lemma = source["lemma"];
+	        this.merged = source["merged"];
+	    }
+	}
+	export class RowError {
+	    row: number;
+	    word: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RowError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.row = source["row"];
+	        this.word = source["word"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ImportResult {
+	    added: number;
+	    existing: number;
+	    merged: number;
+	    forms: FormMatch[];
+	    errors: RowError[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.added = source["added"];
+	        this.existing = source["existing"];
+	        this.merged = source["merged"];
+	        this.forms = this.convertValues(source["forms"], FormMatch);
+	        this.errors = this.convertValues(source["errors"], RowError);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Profile {
+	    id: number;
+	    name: string;
+	    active: boolean;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Profile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.active = source["active"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	
+	export class ReviewLog {
+	    id: number;
+	    profileId: number;
+	    wordId: number;
+	    cardId: number;
+	    reviewedAt: number;
+	    state: string;
+	    quality: number;
+	    prevInterval: number;
+	    newInterval: number;
+	    prevEase: number;
+	    newEase: number;
+	    responseTime: number;
+	    cardType: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewLog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.profileId = source["profileId"];
+	        this.wordId = source["wordId"];
+	        this.cardId = source["cardId"];
+	        this.reviewedAt = source["reviewedAt"];
+	        this.state = source["state"];
+	        this.quality = source["quality"];
+	        this.prevInterval = source["prevInterval"];
+	        this.newInterval = source["newInterval"];
+	        this.prevEase = source["prevEase"];
+	        this.newEase = source["newEase"];
+	        this.responseTime = source["responseTime"];
+	        this.cardType = source["cardType"];
+	    }
+	}
+	
+	export class SearchResult {
+	    word: Word;
+	    rank: number;
+	    highlights: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = this.convertValues(source["word"], Word);
+	        this.rank = source["rank"];
+	        this.highlights = source["highlights"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class SimulationParams {
+	    days: number;
+	    newPerDay: number;
+	    desiredRetention: number;
+	    scheduler: string;
+	    additionalWords: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SimulationParams(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = source["days"];
+	        this.newPerDay = source["newPerDay"];
+	        this.desiredRetention = source["desiredRetention"];
+	        this.scheduler = source["scheduler"];
+	        this.additionalWords = source["additionalWords"];
+	    }
+	}
+	export class SimulationResult {
+	    days: ForecastDay[];
+	    totalReviews: number;
+	    averageReviews: number;
+	    peakReviews: number;
+	    learnedWords: number;
+	    remainingNew: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SimulationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = this.convertValues(source["days"], ForecastDay);
+	        this.totalReviews = source["totalReviews"];
+	        this.averageReviews = source["averageReviews"];
+	        this.peakReviews = source["peakReviews"];
+	        this.learnedWords = source["learnedWords"];
+	        this.remainingNew = source["remainingNew"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StudyQueue {
+	    cards: Card[];
+	    learningCount: number;
+	    reviewCount: number;
+	    newCount: number;
+	    newDoneToday: number;
+	    reviewsDoneToday: number;
+	    newLimit: number;
+	    reviewLimit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StudyQueue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cards = this.convertValues(source["cards"], Card);
+	        this.learningCount = source["learningCount"];
+	        this.reviewCount = source["reviewCount"];
+	        this.newCount = source["newCount"];
+	        this.newDoneToday = source["newDoneToday"];
+	        this.reviewsDoneToday = source["reviewsDoneToday"];
+	        this.newLimit = source["newLimit"];
+	        this.reviewLimit = source["reviewLimit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StudySettings {
+	    id: number;
+	    scheduler: string;
+	    desiredRetention: number;
+	    maximumInterval: number;
+	    learningSteps: string;
+	    relearningSteps: string;
+	    easyInterval: number;
+	    learnAheadLimit: number;
+	    leechThreshold: number;
+	    leechAction: string;
+	    newPerDay: number;
+	    reviewsPerDay: number;
+	    reviewOrder: string;
+	    newCardMix: string;
+	    dayStartHour: number;
+	    fuzz: boolean;
+	    loadBalance: boolean;
+	    cardTypes: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StudySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.scheduler = source["scheduler"];
+	        this.desiredRetention = source["desiredRetention"];
+	        this.maximumInterval = source["maximumInterval"];
+	        this.learningSteps = source["learningSteps"];
+	        this.relearningSteps = source["relearningSteps"];
+	        this.easyInterval = source["easyInterval"];
+	        this.learnAheadLimit = source["learnAheadLimit"];
+	        this.leechThreshold = source["leechThreshold"];
+	        this.leechAction = source["leechAction"];
+	        this.newPerDay = source["newPerDay"];
+	        this.reviewsPerDay = source["reviewsPerDay"];
+	        this.reviewOrder = source["reviewOrder"];
+	        this.newCardMix = source["newCardMix"];
+	        this.dayStartHour = source["dayStartHour"];
+	        this.fuzz = source["fuzz"];
+	        this.loadBalance = source["loadBalance"];
+	        this.cardTypes = source["cardTypes"];
+	    }
+	}
+	
+	export class TrashedWord {
+	    word: Word;
+	    deletedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrashedWord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = this.convertValues(source["word"], Word);
+	        this.deletedAt = source["deletedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class WordListParams {
+	    offset: number;
+	    limit: number;
+	    sort: string;
+	    descending: boolean;
+	    query: string;
+	    learned?: boolean;
+	    mastered?: boolean;
+	    due: boolean;
+	    difficulty?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WordListParams(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	        this.sort = source["sort"];
+	        this.descending = source["descending"];
+	        this.query = source["query"];
 	        this.learned = source["learned"];
 	        this.mastered = source["mastered"];
+	        this.due = source["due"];
+	        this.difficulty = source["difficulty"];
 	    }
 	}
+	export class WordPage {
+	    words: Word[];
+	    total: number;
+	    offset: number;
+	    limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WordPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.words = this.convertValues(source["words"], Word);
+	        this.total = source["total"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WordRevision {
+	    id: number;
+	    wordId: number;
+	    source: string;
+	    revertedTo?: number;
+	    createdAt: number;
+	    changes: FieldChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WordRevision(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.wordId = source["wordId"];
+	        this.source = source["source"];
+	        this.revertedTo = source["revertedTo"];
+	        this.createdAt = source["createdAt"];
+	        this.changes = this.convertValues(source["changes"], FieldChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
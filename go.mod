@@ -6,6 +6,7 @@ toolchain go1.24.1
 
 require (
 	github.com/wailsapp/wails/v2 v2.10.1
+	golang.org/x/text v0.23.0
 	gorm.io/driver/sqlite v1.5.7
 	gorm.io/gorm v1.25.12
 )
@@ -39,7 +40,6 @@ require (
 	golang.org/x/crypto v0.33.0 // indirect
 	golang.org/x/net v0.35.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
-	golang.org/x/text v0.23.0 // indirect
 )
 
 // replace github.com/wailsapp/wails/v2 v2.10.1 => D:\Go\gopath\go\pkg\mod
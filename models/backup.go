@@ -0,0 +1,18 @@
+package models
+
+// 备份原因
+const (
+	BackupReasonAuto      = "auto"      // 定时自动备份
+	BackupReasonManual    = "manual"    // 手动备份
+	BackupReasonImport    = "import"    // 导入前的安全备份
+	BackupReasonRestore   = "restore"   // 恢复备份前的安全备份
+	BackupReasonMigration = "migration" // 数据库升级前的备份
+)
+
+// Backup 表示数据库的一份备份
+type Backup struct {
+	Name      string `json:"name"`      // 备份文件名
+	Reason    string `json:"reason"`    // 备份原因
+	Size      int64  `json:"size"`      // 文件大小(字节)
+	CreatedAt int64  `json:"createdAt"` // 备份时间戳
+}
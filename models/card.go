@@ -0,0 +1,25 @@
+package models
+
+// 卡片类型
+const (
+	CardTypeRecognition = "recognition" // 看英文回忆释义
+	CardTypeRecall      = "recall"      // 看释义回忆英文
+	CardTypeSpelling    = "spelling"    // 听发音拼写单词
+	CardTypeCloze       = "cloze"       // 例句填空
+)
+
+// CardTypes 按学习顺序排列的所有卡片类型
+var CardTypes = []string{CardTypeRecognition, CardTypeRecall, CardTypeSpelling, CardTypeCloze}
+
+// Card 表示学习者对单词的一张学习卡片，同一单词的每张卡片独立调度
+type Card struct {
+	ID          int    `json:"id"`
+	ProfileID   int    `json:"profileId" gorm:"uniqueIndex:idx_cards_profile_word_type"` // 所属学习者ID
+	WordID      int    `json:"wordId" gorm:"uniqueIndex:idx_cards_profile_word_type"`    // 所属单词ID
+	CardType    string `json:"cardType" gorm:"uniqueIndex:idx_cards_profile_word_type"`  // 卡片类型
+	Ord         int    `json:"ord"`                                                      // 卡片类型序号，决定同一单词的卡片学习顺序
+	ReviewState        // 间隔重复调度状态
+	BuriedUntil int64  `json:"buriedUntil"`    // 被同词卡片埋藏到的时间戳
+	Cloze       string `json:"cloze" gorm:"-"` // 填空卡片的题面(挖空后的例句)
+	Word        *Word  `json:"word,omitempty"` // 所属单词
+}
@@ -0,0 +1,8 @@
+package models
+
+// DataDirectory 表示当前使用的数据目录
+type DataDirectory struct {
+	Path    string `json:"path"`    // 数据目录路径
+	Source  string `json:"source"`  // 来源: flag、env、portable、config 或 default
+	Movable bool   `json:"movable"` // 是否可以移动，命令行参数或环境变量指定时不能移动
+}
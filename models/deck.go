@@ -0,0 +1,12 @@
+package models
+
+// Deck 表示单词本，一个单词可以属于多个单词本
+type Deck struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name" gorm:"uniqueIndex"`         // 名称
+	Description string `json:"description"`                     // 描述
+	Scheduler   string `json:"scheduler"`                       // 调度算法，留空则使用全局设置
+	CreatedAt   int64  `json:"createdAt" gorm:"autoCreateTime"` // 创建时间戳
+	WordCount   int    `json:"wordCount" gorm:"-"`              // 单词数量(只读)
+	Words       []Word `json:"-" gorm:"many2many:deck_words"`   // 单词
+}
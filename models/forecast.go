@@ -0,0 +1,28 @@
+package models
+
+// ForecastDay 表示某一天的预测学习量
+type ForecastDay struct {
+	Date     string `json:"date"`     // 日期 YYYY-MM-DD
+	Reviews  int    `json:"reviews"`  // 复习次数
+	NewWords int    `json:"newWords"` // 新学卡片数
+	Lapses   int    `json:"lapses"`   // 预计遗忘次数
+}
+
+// SimulationParams 表示调度模拟参数，零值表示沿用当前设置
+type SimulationParams struct {
+	Days             int     `json:"days"`             // 模拟天数
+	NewPerDay        int     `json:"newPerDay"`        // 每日新卡片数，为0时使用每日新词上限
+	DesiredRetention float64 `json:"desiredRetention"` // 目标记忆保持率，指定时使用FSRS模拟
+	Scheduler        string  `json:"scheduler"`        // 调度算法
+	AdditionalWords  int     `json:"additionalWords"`  // 计划导入的新单词数
+}
+
+// SimulationResult 表示调度模拟结果
+type SimulationResult struct {
+	Days           []ForecastDay `json:"days"`           // 每日预测
+	TotalReviews   int           `json:"totalReviews"`   // 复习总次数
+	AverageReviews float64       `json:"averageReviews"` // 平均每日复习次数
+	PeakReviews    int           `json:"peakReviews"`    // 单日最多复习次数
+	LearnedWords   int           `json:"learnedWords"`   // 模拟期内学习的新卡片数
+	RemainingNew   int           `json:"remainingNew"`   // 模拟结束时仍未学习的卡片数
+}
@@ -0,0 +1,67 @@
+package models
+
+// ImportResult 表示一次导入的结果
+type ImportResult struct {
+	Added    int         `json:"added"`    // 新添加的单词数
+	Existing int         `json:"existing"` // 已存在而跳过的单词数
+	Merged   int         `json:"merged"`   // 作为已有单词的变形合并的单词数
+	Forms    []FormMatch `json:"forms"`    // 识别为其他单词变形的单词
+	Errors   []RowError  `json:"errors"`   // 无法导入的行(CSV导入)
+	Warnings []string    `json:"warnings"` // 导入了但需要注意的单词，如有自己释义而未合并的变形
+}
+
+// RowError 表示导入文件中无法导入的一行
+type RowError struct {
+	Row     int    `json:"row"`     // 行号，从1开始，包括表头
+	Word    string `json:"word"`    // 该行的单词(如果有)
+	Message string `json:"message"` // 错误原因
+}
+
+// FormMatch 表示导入的单词是另一个单词的屈折变形，如 ran 是 run 的变形
+type FormMatch struct {
+	Word   string `json:"word"`   // 导入的单词
+	Lemma  string `json:"lemma"`  // 词库或导入文件中的原形
+	Merged bool   `json:"merged"` // 是否已合并到原形，否则仍作为独立单词添加
+}
+
+// LegacyImportResult 表示旧版 words.json 数据迁移到数据库的结果
+type LegacyImportResult struct {
+	Added    int    `json:"added"`    // 新添加的单词数
+	Existing int    `json:"existing"` // 已在词库中的单词数
+	Progress int    `json:"progress"` // 保留了学习进度的单词数
+	Skipped  int    `json:"skipped"`  // 没有单词文本而跳过的条目数
+	Archive  string `json:"archive"`  // 原文件归档后的路径
+}
+
+// CSV导入时可以映射到的单词字段，列映射中的空字符串表示忽略该列
+const (
+	CSVFieldWord        = "word"        // 单词(必需)
+	CSVFieldPhonetic    = "phonetic"    // 音标
+	CSVFieldPOS         = "pos"         // 词性
+	CSVFieldDefinition  = "definition"  // 释义(必需)
+	CSVFieldExample     = "example"     // 例句
+	CSVFieldTranslation = "translation" // 例句翻译
+	CSVFieldImageURL    = "imageUrl"    // 图片URL
+	CSVFieldDifficulty  = "difficulty"  // 难度 1-5
+	CSVFieldTags        = "tags"        // 标签，多个标签用空格、逗号或分号分隔
+)
+
+// CSVImportOptions CSV/TSV导入选项
+type CSVImportOptions struct {
+	Delimiter  string   `json:"delimiter"`  // 分隔符，为空时自动检测
+	Encoding   string   `json:"encoding"`   // 文件编码 utf-8、utf-16le、utf-16be 或 gbk，为空时自动检测
+	HasHeader  bool     `json:"hasHeader"`  // 第一行是否为表头
+	Columns    []string `json:"columns"`    // 每一列对应的单词字段，按列顺序排列
+	DeckID     int      `json:"deckId"`     // 不为0时将导入的单词加入该单词本
+	MergeForms bool     `json:"mergeForms"` // 是否将变形合并到原形
+}
+
+// CSVPreview CSV/TSV文件的预览，包含检测出的格式和建议的列映射
+type CSVPreview struct {
+	Delimiter string     `json:"delimiter"` // 检测出的分隔符
+	Encoding  string     `json:"encoding"`  // 检测出的编码
+	HasHeader bool       `json:"hasHeader"` // 第一行是否像表头
+	Rows      [][]string `json:"rows"`      // 文件开头的几行
+	Columns   []string   `json:"columns"`   // 建议的列映射
+	Fields    []string   `json:"fields"`    // 可以映射的字段
+}
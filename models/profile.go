@@ -0,0 +1,12 @@
+package models
+
+// Profile 表示一个学习者，词库在学习者之间共享，学习进度和学习设置各自独立
+type Profile struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name" gorm:"uniqueIndex"`         // 名称
+	Active    bool   `json:"active"`                          // 是否为当前学习者，下次启动时沿用
+	CreatedAt int64  `json:"createdAt" gorm:"autoCreateTime"` // 创建时间戳
+}
+
+// DefaultProfileID 升级前已有的学习进度归属的默认学习者
+const DefaultProfileID = 1
@@ -0,0 +1,30 @@
+package models
+
+// 单词关系类型
+const (
+	RelationSynonym     = "synonym"     // 近义词
+	RelationAntonym     = "antonym"     // 反义词
+	RelationDerivedFrom = "derivedFrom" // 派生自(有方向：单词派生自关联单词)
+	RelationDerivation  = "derivation"  // 派生词(派生自的反向关系)
+	RelationConfusable  = "confusable"  // 易混词
+	RelationCollocate   = "collocate"   // 常用搭配
+)
+
+// RelationTypes 可以添加的单词关系类型
+var RelationTypes = []string{RelationSynonym, RelationAntonym, RelationDerivedFrom, RelationDerivation, RelationConfusable, RelationCollocate}
+
+// WordRelation 表示两个单词之间的一条关系
+// 除派生关系外都是对称关系，只保存一条 WordID < RelatedID 的记录
+type WordRelation struct {
+	ID        int    `json:"id"`
+	WordID    int    `json:"wordId" gorm:"uniqueIndex:idx_word_relations_pair;index"`    // 单词ID
+	RelatedID int    `json:"relatedId" gorm:"uniqueIndex:idx_word_relations_pair;index"` // 关联单词ID
+	Type      string `json:"type" gorm:"uniqueIndex:idx_word_relations_pair"`            // 关系类型
+}
+
+// RelatedWord 表示从某个单词看到的一个关联单词
+type RelatedWord struct {
+	ID   int    `json:"id,omitempty"` // 关联单词ID，导入时可省略
+	Word string `json:"word"`         // 关联单词
+	Type string `json:"type"`         // 关系类型
+}
@@ -0,0 +1,18 @@
+package models
+
+// ReviewLog 表示一次复习记录
+type ReviewLog struct {
+	ID           int     `json:"id"`
+	ProfileID    int     `json:"profileId" gorm:"index"`  // 学习者ID
+	WordID       int     `json:"wordId" gorm:"index"`     // 单词ID
+	CardID       int     `json:"cardId" gorm:"index"`     // 卡片ID
+	ReviewedAt   int64   `json:"reviewedAt" gorm:"index"` // 复习时间戳
+	State        string  `json:"state"`                   // 作答前的卡片状态
+	Quality      int     `json:"quality"`                 // 回答质量 0-5
+	PrevInterval int     `json:"prevInterval"`            // 复习前间隔(天)
+	NewInterval  int     `json:"newInterval"`             // 复习后间隔(天)
+	PrevEase     float64 `json:"prevEase"`                // 复习前简易度因子
+	NewEase      float64 `json:"newEase"`                 // 复习后简易度因子
+	ResponseTime int64   `json:"responseTime"`            // 作答用时(毫秒)
+	CardType     string  `json:"cardType"`                // 卡片类型
+}
@@ -0,0 +1,27 @@
+package models
+
+// 修订来源
+const (
+	RevisionSourceInitial = "initial" // 开始记录修订历史时已有的内容
+	RevisionSourceManual  = "manual"  // 手动添加或编辑
+	RevisionSourceImport  = "import"  // 导入
+	RevisionSourceRevert  = "revert"  // 恢复到历史版本
+)
+
+// WordRevision 表示对单词内容的一次修改，只记录有变化的字段
+// 单词的第一条修订记录添加时(或开始记录历史时)的完整内容
+type WordRevision struct {
+	ID         int           `json:"id"`
+	WordID     int           `json:"wordId" gorm:"index"`             // 单词ID
+	Source     string        `json:"source"`                          // 修改来源
+	RevertedTo int           `json:"revertedTo,omitempty"`            // 恢复操作恢复到的修订ID
+	CreatedAt  int64         `json:"createdAt" gorm:"autoCreateTime"` // 修改时间戳
+	Changes    []FieldChange `json:"changes" gorm:"serializer:json"`  // 字段变化
+}
+
+// FieldChange 表示一个内容字段的变化，义项(senses)以JSON文本表示
+type FieldChange struct {
+	Field string `json:"field"` // 字段名，与单词的JSON字段名一致
+	Old   string `json:"old"`   // 修改前的值
+	New   string `json:"new"`   // 修改后的值
+}
@@ -0,0 +1,8 @@
+package models
+
+// SearchResult 表示一条全文搜索结果
+type SearchResult struct {
+	Word       Word              `json:"word"`       // 命中的单词
+	Rank       float64           `json:"rank"`       // 相关度得分，越小越相关
+	Highlights map[string]string `json:"highlights"` // 命中字段的高亮片段(已转义的HTML，命中部分用<mark>标记)，键为字段名
+}
@@ -0,0 +1,20 @@
+package models
+
+// Sense 表示单词的一个义项，如 book 的名词和动词义项
+type Sense struct {
+	ID           int            `json:"id"`
+	WordID       int            `json:"wordId" gorm:"index"` // 所属单词ID
+	Ord          int            `json:"ord"`                 // 义项顺序
+	PartOfSpeech string         `json:"pos"`                 // 词性，如 n. / v.
+	Definition   string         `json:"definition"`          // 释义
+	Examples     []SenseExample `json:"examples,omitempty"`  // 例句
+}
+
+// SenseExample 表示义项下的一条例句及其翻译
+type SenseExample struct {
+	ID          int    `json:"id"`
+	SenseID     int    `json:"senseId" gorm:"index"` // 所属义项ID
+	Ord         int    `json:"ord"`                  // 例句顺序
+	Text        string `json:"text"`                 // 例句
+	Translation string `json:"translation"`          // 例句翻译
+}
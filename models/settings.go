@@ -0,0 +1,66 @@
+package models
+
+// StudySettings 表示学习者的学习与调度设置，ID与学习者ID相同
+type StudySettings struct {
+	ID               int     `json:"id"`
+	Scheduler        string  `json:"scheduler"`                              // 调度算法: sm2 / fsrs
+	DesiredRetention float64 `json:"desiredRetention"`                       // 目标记忆保持率 (FSRS)
+	MaximumInterval  int     `json:"maximumInterval"`                        // 最大复习间隔(天)
+	LearningSteps    string  `json:"learningSteps" gorm:"default:'1m 10m'"`  // 新词学习步骤，如 "1m 10m 1h"
+	RelearningSteps  string  `json:"relearningSteps" gorm:"default:'10m'"`   // 遗忘后重新学习步骤，如 "10m"
+	EasyInterval     int     `json:"easyInterval" gorm:"default:4"`          // 学习阶段选择"非常简单"时的间隔(天)
+	LearnAheadLimit  int     `json:"learnAheadLimit" gorm:"default:20"`      // 没有其他到期卡片时提前取回学习中卡片的时间窗口(分钟)
+	LeechThreshold   int     `json:"leechThreshold" gorm:"default:8"`        // 遗忘多少次后标记为顽固词
+	LeechAction      string  `json:"leechAction" gorm:"default:'suspend'"`   // 顽固词处理方式: suspend / tag
+	NewPerDay        int     `json:"newPerDay" gorm:"default:20"`            // 每日新词上限
+	ReviewsPerDay    int     `json:"reviewsPerDay" gorm:"default:200"`       // 每日复习上限
+	ReviewOrder      string  `json:"reviewOrder" gorm:"default:'overdue'"`   // 复习排序: due / overdue / retrievability
+	NewCardMix       string  `json:"newCardMix" gorm:"default:'mix'"`        // 新词与复习的混合方式: mix / before / after
+	DayStartHour     int     `json:"dayStartHour" gorm:"default:4"`          // 每日学习的起始小时
+	Fuzz             bool    `json:"fuzz" gorm:"default:true"`               // 是否对复习间隔加入随机扰动
+	LoadBalance      bool    `json:"loadBalance"`                            // 是否在扰动范围内选择复习量最少的一天
+	CardTypes        string  `json:"cardTypes" gorm:"default:'recognition'"` // 启用的卡片类型
+}
+
+// DefaultStudySettings 返回默认学习设置
+func DefaultStudySettings() StudySettings {
+	return StudySettings{
+		ID:               DefaultProfileID,
+		Scheduler:        "sm2",
+		DesiredRetention: 0.9,
+		MaximumInterval:  36500,
+		LearningSteps:    "1m 10m",
+		RelearningSteps:  "10m",
+		EasyInterval:     4,
+		LearnAheadLimit:  20,
+		LeechThreshold:   8,
+		LeechAction:      LeechActionSuspend,
+		NewPerDay:        20,
+		ReviewsPerDay:    200,
+		ReviewOrder:      ReviewOrderOverdue,
+		NewCardMix:       NewCardMixInterleave,
+		DayStartHour:     4,
+		Fuzz:             true,
+		CardTypes:        CardTypeRecognition, // 学习和复习页面目前只支持识别卡片
+	}
+}
+
+// 顽固词处理方式
+const (
+	LeechActionSuspend = "suspend" // 标记并暂停
+	LeechActionTag     = "tag"     // 仅标记
+)
+
+// 复习排序方式
+const (
+	ReviewOrderDue            = "due"            // 按到期时间
+	ReviewOrderOverdue        = "overdue"        // 按相对逾期程度，逾期越多越靠前
+	ReviewOrderRetrievability = "retrievability" // 按回忆概率，越容易遗忘越靠前
+)
+
+// 新词与复习的混合方式
+const (
+	NewCardMixInterleave = "mix"    // 新词均匀穿插在复习中
+	NewCardMixBefore     = "before" // 先学新词
+	NewCardMixAfter      = "after"  // 复习完成后再学新词
+)
@@ -0,0 +1,13 @@
+package models
+
+// StudyQueue 表示一次学习会话的队列
+type StudyQueue struct {
+	Cards            []Card `json:"cards"`            // 按学习顺序排列的卡片
+	LearningCount    int    `json:"learningCount"`    // 学习中/重新学习中的卡片数
+	ReviewCount      int    `json:"reviewCount"`      // 待复习的卡片数
+	NewCount         int    `json:"newCount"`         // 新卡片数
+	NewDoneToday     int    `json:"newDoneToday"`     // 今日已学新卡片数
+	ReviewsDoneToday int    `json:"reviewsDoneToday"` // 今日已复习数
+	NewLimit         int    `json:"newLimit"`         // 今日新卡片上限
+	ReviewLimit      int    `json:"reviewLimit"`      // 今日复习上限
+}
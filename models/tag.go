@@ -0,0 +1,9 @@
+package models
+
+// Tag 表示单词标签，标签名统一为小写且不含空白
+type Tag struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name" gorm:"uniqueIndex"`      // 名称
+	WordCount int    `json:"wordCount" gorm:"-"`           // 单词数量(只读)
+	Words     []Word `json:"-" gorm:"many2many:word_tags"` // 单词
+}
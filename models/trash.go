@@ -0,0 +1,7 @@
+package models
+
+// TrashedWord 表示回收站中的单词
+type TrashedWord struct {
+	Word      Word  `json:"word"`      // 单词
+	DeletedAt int64 `json:"deletedAt"` // 移入回收站的时间戳
+}
@@ -1,26 +1,61 @@
 package models
 
-// Word 表示单词数据模型
+import "gorm.io/gorm"
+
+// Word 表示单词词条(词典内容)
+// 学习进度保存在每张卡片(Card)中，不随词条一起编辑。
+// 义项(Senses)是释义和例句的完整内容，Definition/Example/Translation 由义项汇总得出，
+// 只提供这三个字段时视为只有一个义项
 type Word struct {
-	ID            int    `json:"id"`
-	Word          string `json:"word"`           // 单词
-	Phonetic      string `json:"phonetic"`       // 音标
-	Pronunciation string `json:"pronunciation"` // 发音文件路径
-	Definition    string `json:"definition"`     // 释义
-	Example       string `json:"example"`        // 例句
-	Translation   string `json:"translation"`    // 例句翻译
-	ImageURL      string `json:"imageUrl"`       // 图片URL
-	Difficulty    int    `json:"difficulty"`     // 难度级别 1-5
-	LastReviewed  int64  `json:"lastReviewed"`   // 上次复习时间戳
-	NextReview    int64  `json:"nextReview"`     // 下次复习时间戳
-	ReviewCount   int    `json:"reviewCount"`    // 复习次数
-	EaseFactor    float64 `json:"easeFactor"`    // 简易度因子 (用于间隔重复算法)
-	Interval      int    `json:"interval"`       // 复习间隔(天)
-	Learned       bool   `json:"learned"`        // 是否已学习
-	Mastered      bool   `json:"mastered"`       // 是否已掌握
+	ID            int            `json:"id"`
+	Word          string         `json:"word"`                                        // 单词
+	Key           string         `json:"-" gorm:"column:word_key"`                    // 唯一键(小写并合并空白后的单词)，由唯一索引 idx_words_key 约束
+	Phonetic      string         `json:"phonetic"`                                    // 音标
+	Pronunciation string         `json:"pronunciation"`                               // 发音文件路径
+	Definition    string         `json:"definition"`                                  // 释义(所有义项汇总)
+	Example       string         `json:"example"`                                     // 例句(第一条例句)
+	Translation   string         `json:"translation"`                                 // 例句翻译(第一条例句的翻译)
+	ImageURL      string         `json:"imageUrl"`                                    // 图片URL
+	Difficulty    int            `json:"difficulty"`                                  // 难度级别 1-5
+	CreatedAt     int64          `json:"createdAt" gorm:"autoCreateTime"`             // 添加时间戳(早期版本添加的单词为0)
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`                              // 移入回收站的时间，为空表示未删除
+	Learned       bool           `json:"learned" gorm:"-"`                            // 是否已学习(由识别卡片得出，只读)
+	Mastered      bool           `json:"mastered" gorm:"-"`                           // 是否已掌握(由识别卡片得出，只读)
+	Senses        []Sense        `json:"senses,omitempty"`                            // 义项
+	Related       []RelatedWord  `json:"related,omitempty" gorm:"-"`                  // 关联单词(近义词、易混词等)
+	Cards         []Card         `json:"cards,omitempty"`                             // 学习卡片及进度
+	Decks         []Deck         `json:"decks,omitempty" gorm:"many2many:deck_words"` // 所属单词本
+	Tags          []Tag          `json:"tags,omitempty" gorm:"many2many:word_tags"`   // 标签
+}
+
+// ReviewState 表示间隔重复算法使用的调度状态
+type ReviewState struct {
+	State            string  `json:"state" gorm:"index"` // 卡片状态: new / learning / review / relearning
+	Step             int     `json:"step"`               // 当前所处的(重新)学习步骤
+	LastReviewed     int64   `json:"lastReviewed"`       // 上次复习时间戳
+	NextReview       int64   `json:"nextReview"`         // 下次复习时间戳
+	ReviewCount      int     `json:"reviewCount"`        // 复习次数
+	EaseFactor       float64 `json:"easeFactor"`         // 简易度因子 (SM-2)
+	Interval         int     `json:"interval"`           // 复习间隔(天)
+	Stability        float64 `json:"stability"`          // 记忆稳定性(天) (FSRS)
+	MemoryDifficulty float64 `json:"memoryDifficulty"`   // 记忆难度 1-10 (FSRS)
+	Lapses           int     `json:"lapses"`             // 遗忘次数
+	Leech            bool    `json:"leech"`              // 是否为顽固词(多次遗忘)
+	Suspended        bool    `json:"suspended"`          // 是否已暂停学习
 }
 
+// MasteredInterval 复习间隔达到该天数即视为已掌握
+const MasteredInterval = 30
+
+// 卡片状态
+const (
+	StateNew        = "new"        // 未学习
+	StateLearning   = "learning"   // 学习中(分钟级步骤)
+	StateReview     = "review"     // 复习中(天级间隔)
+	StateRelearning = "relearning" // 遗忘后重新学习
+)
+
 // WordList 表示单词列表
 type WordList struct {
 	Words []Word `json:"words"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,30 @@
+package models
+
+// 单词列表排序方式
+const (
+	WordSortAlphabetical = "word"       // 按字母顺序
+	WordSortNextReview   = "nextReview" // 按下次复习时间，未学习的单词排在最后
+	WordSortEase         = "ease"       // 按简易度因子
+	WordSortCreated      = "created"    // 按添加时间
+)
+
+// WordListParams 表示单词列表的分页、排序和筛选参数，筛选条件为空表示不限制
+type WordListParams struct {
+	Offset     int    `json:"offset"`               // 跳过的单词数
+	Limit      int    `json:"limit"`                // 每页单词数，0表示默认值
+	Sort       string `json:"sort"`                 // 排序方式，默认按添加时间
+	Descending bool   `json:"descending"`           // 是否倒序
+	Query      string `json:"query"`                // 查询语句，如 "tag:verb deck:ielts"
+	Learned    *bool  `json:"learned,omitempty"`    // 是否已学习
+	Mastered   *bool  `json:"mastered,omitempty"`   // 是否已掌握
+	Due        bool   `json:"due"`                  // 只包含当前已到期的单词
+	Difficulty int    `json:"difficulty,omitempty"` // 难度级别 1-5
+}
+
+// WordPage 表示一页单词及筛选后的总数
+type WordPage struct {
+	Words  []Word `json:"words"`  // 当前页的单词
+	Total  int    `json:"total"`  // 符合条件的单词总数
+	Offset int    `json:"offset"` // 当前页起始位置
+	Limit  int    `json:"limit"`  // 每页单词数
+}
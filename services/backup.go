@@ -0,0 +1,308 @@
+package services
+
+import (
+	"WordMaster/models"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// autoBackupInterval 两次自动备份的最小间隔
+const autoBackupInterval = 24 * time.Hour
+
+// backupRetention 各类自动产生的备份保留的最近份数，手动备份和升级前的备份不会自动删除
+var backupRetention = map[string]int{
+	models.BackupReasonAuto:    7,
+	models.BackupReasonImport:  5,
+	models.BackupReasonRestore: 5,
+}
+
+// backupNamePattern 备份文件名，如 words-auto-20240102-150405.db，升级前的备份原因为 v版本号
+var backupNamePattern = regexp.MustCompile(`^words-([a-z0-9]+)-\d{8}-\d{6}(-\d+)?\.db$`)
+
+// migrationReasonPattern 升级前备份的原因部分
+var migrationReasonPattern = regexp.MustCompile(`^v\d+$`)
+
+// backupDir 返回备份目录
+func backupDir(dataDir string) string {
+	return filepath.Join(dataDir, "backups")
+}
+
+// backupDatabase 使用 VACUUM INTO 将数据库在线备份到 backups 目录，返回备份文件路径
+func backupDatabase(db *gorm.DB, dataDir string, reason string) (string, error) {
+	dir := backupDir(dataDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	backupPath := uniqueBackupPath(dir, fmt.Sprintf("words-%s-%s", reason, time.Now().Format("20060102-150405")), ".db")
+	if err := db.Exec("VACUUM INTO ?", backupPath).Error; err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// uniqueBackupPath 返回备份目录中不存在的文件路径，文件已存在时在 base 后加序号
+func uniqueBackupPath(dir, base, ext string) string {
+	path := filepath.Join(dir, base+ext)
+	for i := 2; ; i++ {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%d%s", base, i, ext))
+	}
+}
+
+// ListBackups 获取备份目录中的所有备份，最近的排在前面
+func (s *WordService) ListBackups() ([]models.Backup, error) {
+	entries, err := os.ReadDir(backupDir(s.dataDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return []models.Backup{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []models.Backup{}
+	modTimes := make(map[string]time.Time)
+	for _, entry := range entries {
+		match := backupNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		reason := match[1]
+		if migrationReasonPattern.MatchString(reason) {
+			reason = models.BackupReasonMigration
+		}
+		backups = append(backups, models.Backup{
+			Name:      entry.Name(),
+			Reason:    reason,
+			Size:      info.Size(),
+			CreatedAt: info.ModTime().Unix(),
+		})
+		modTimes[entry.Name()] = info.ModTime()
+	}
+	slices.SortFunc(backups, func(a, b models.Backup) int {
+		return cmp.Or(modTimes[b.Name].Compare(modTimes[a.Name]),
+			strings.Compare(strings.TrimSuffix(b.Name, ".db"), strings.TrimSuffix(a.Name, ".db")))
+	})
+	return backups, nil
+}
+
+// CreateBackup 立即备份数据库
+func (s *WordService) CreateBackup() (models.Backup, error) {
+	return s.backup(models.BackupReasonManual)
+}
+
+// backup 备份数据库，不与其他备份或恢复同时进行
+func (s *WordService) backup(reason string) (models.Backup, error) {
+	s.backupMu.Lock()
+	defer s.backupMu.Unlock()
+	backup, err := s.createBackup(reason)
+	if err != nil {
+		return models.Backup{}, err
+	}
+	return backup, s.pruneBackups(reason)
+}
+
+// AutoBackup 距离上次自动备份超过一天时备份数据库，返回是否进行了备份
+func (s *WordService) AutoBackup() (bool, error) {
+	s.backupMu.Lock()
+	defer s.backupMu.Unlock()
+	if s.db == nil {
+		return false, errDatabaseClosed
+	}
+
+	backups, err := s.ListBackups()
+	if err != nil {
+		return false, err
+	}
+	for _, backup := range backups {
+		if backup.Reason == models.BackupReasonAuto &&
+			time.Since(time.Unix(backup.CreatedAt, 0)) < autoBackupInterval {
+			return false, nil
+		}
+	}
+	if _, err := s.createBackup(models.BackupReasonAuto); err != nil {
+		return false, err
+	}
+	return true, s.pruneBackups(models.BackupReasonAuto)
+}
+
+// errDatabaseClosed 数据库已关闭(如服务已关闭或正在恢复备份)时返回的错误
+var errDatabaseClosed = errors.New("database is closed")
+
+// createBackup 备份数据库，旧备份由调用方通过 pruneBackups 清理
+func (s *WordService) createBackup(reason string) (models.Backup, error) {
+	if s.db == nil {
+		return models.Backup{}, errDatabaseClosed
+	}
+	backupPath, err := backupDatabase(s.db, s.dataDir, reason)
+	if err != nil {
+		return models.Backup{}, fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	info, err := os.Stat(backupPath)
+	if err != nil {
+		return models.Backup{}, err
+	}
+	return models.Backup{
+		Name:      filepath.Base(backupPath),
+		Reason:    reason,
+		Size:      info.Size(),
+		CreatedAt: info.ModTime().Unix(),
+	}, nil
+}
+
+// pruneBackups 删除超出保留份数的旧备份
+func (s *WordService) pruneBackups(reason string) error {
+	keep, ok := backupRetention[reason]
+	if !ok {
+		return nil
+	}
+	backups, err := s.ListBackups()
+	if err != nil {
+		return err
+	}
+	for _, backup := range backups {
+		if backup.Reason != reason {
+			continue
+		}
+		if keep > 0 {
+			keep--
+			continue
+		}
+		if err := os.Remove(filepath.Join(backupDir(s.dataDir), backup.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreBackup 用备份替换当前数据库，恢复前先备份当前数据库
+// 备份来自旧版本时恢复后会自动升级；恢复失败时还原为恢复前的数据库
+func (s *WordService) RestoreBackup(name string) error {
+	s.backupMu.Lock()
+	defer s.backupMu.Unlock()
+
+	if !backupNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid backup name '%s'", name)
+	}
+	backupPath := filepath.Join(backupDir(s.dataDir), name)
+	if err := checkBackup(backupPath); err != nil {
+		return fmt.Errorf("backup '%s' is not usable: %w", name, err)
+	}
+
+	safety, err := s.createBackup(models.BackupReasonRestore)
+	if err != nil {
+		return err
+	}
+	safetyPath := filepath.Join(backupDir(s.dataDir), safety.Name)
+
+	if err := s.replaceDatabase(backupPath); err != nil {
+		return s.rollbackRestore(safetyPath, err)
+	}
+	if err := s.open(); err != nil {
+		return s.rollbackRestore(safetyPath, err)
+	}
+	// 要恢复的备份可能是较早的安全备份，恢复完成后才清理
+	return s.pruneBackups(models.BackupReasonRestore)
+}
+
+// rollbackRestore 恢复失败后换回恢复前的数据库
+func (s *WordService) rollbackRestore(safetyPath string, cause error) error {
+	if err := s.replaceDatabase(safetyPath); err != nil {
+		return fmt.Errorf("failed to restore backup: %v; rolling back also failed: %w", cause, err)
+	}
+	if err := s.open(); err != nil {
+		return fmt.Errorf("failed to restore backup: %v; reopening database also failed: %w", cause, err)
+	}
+	return fmt.Errorf("failed to restore backup: %w", cause)
+}
+
+// replaceDatabase 关闭数据库并用 source 文件替换数据库文件
+// 先复制到临时文件再重命名，避免复制中断后数据库文件不完整
+func (s *WordService) replaceDatabase(source string) error {
+	if s.db != nil {
+		if err := closeDB(s.db); err != nil {
+			return err
+		}
+		s.db = nil
+	}
+
+	dbPath := s.dbPath()
+	tmpPath := dbPath + ".restore"
+	if err := copyFile(source, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	// 删除旧数据库的日志文件，以免被应用到新的数据库上
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(tmpPath, dbPath)
+}
+
+// checkBackup 检查备份文件是完整的单词数据库
+func checkBackup(backupPath string) error {
+	// 打开不存在的文件会创建空数据库
+	if _, err := os.Stat(backupPath); err != nil {
+		return err
+	}
+	db, err := gorm.Open(sqlite.Open(backupPath), &gorm.Config{})
+	if err != nil {
+		return err
+	}
+	defer closeDB(db)
+
+	var result string
+	if err := db.Raw("PRAGMA quick_check").Scan(&result).Error; err != nil {
+		return err
+	}
+	if result != "ok" {
+		return errors.New(result)
+	}
+	if !db.Migrator().HasTable(&models.Word{}) {
+		return errors.New("no words table")
+	}
+	return nil
+}
+
+// copyFile 复制文件内容
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"WordMaster/models"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ParseCardTypes 解析卡片类型列表字符串，如 "recognition recall"
+func ParseCardTypes(cardTypes string) ([]string, error) {
+	fields := strings.FieldsFunc(cardTypes, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	for _, cardType := range fields {
+		if !slices.Contains(models.CardTypes, cardType) {
+			return nil, fmt.Errorf("unknown card type '%s'", cardType)
+		}
+	}
+	return fields, nil
+}
+
+// enabledCardTypes 返回设置中启用的卡片类型
+func (s *WordService) enabledCardTypes() []string {
+	cardTypes, _ := ParseCardTypes(s.settings.CardTypes)
+	return cardTypes
+}
+
+// cardTypeOrd 返回卡片类型的序号
+func cardTypeOrd(cardType string) int {
+	return slices.Index(models.CardTypes, cardType)
+}
+
+// clozePattern 返回匹配例句中单词(及其词形变化)的正则
+func clozePattern(word string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(strings.TrimSpace(word)) + `\w*`)
+}
+
+// ClozeText 将例句中的单词挖空，例句中不含该单词时返回false
+func ClozeText(example string, word string) (string, bool) {
+	if strings.TrimSpace(word) == "" || example == "" {
+		return "", false
+	}
+	pattern := clozePattern(word)
+	if !pattern.MatchString(example) {
+		return "", false
+	}
+	return pattern.ReplaceAllString(example, "____"), true
+}
+
+// wordCardTypes 返回单词应有的卡片类型
+// 识别卡片总是存在，单词级接口以它代表单词的学习进度；填空卡片需要例句中包含该单词
+func (s *WordService) wordCardTypes(word models.Word) []string {
+	cardTypes := []string{models.CardTypeRecognition}
+	for _, cardType := range s.enabledCardTypes() {
+		if cardType == models.CardTypeRecognition || slices.Contains(cardTypes, cardType) {
+			continue
+		}
+		if cardType == models.CardTypeCloze {
+			if _, ok := ClozeText(word.Example, word.Word); !ok {
+				continue
+			}
+		}
+		cardTypes = append(cardTypes, cardType)
+	}
+	return cardTypes
+}
+
+// ensureWordCards 为当前学习者补齐单词缺少的卡片，新卡片从未学习状态开始
+func (s *WordService) ensureWordCards(tx *gorm.DB, word models.Word, existing []string) error {
+	var cards []models.Card
+	for _, cardType := range s.wordCardTypes(word) {
+		if slices.Contains(existing, cardType) {
+			continue
+		}
+
+		card := models.Card{
+			ProfileID: s.profileID,
+			WordID:    word.ID,
+			CardType:  cardType,
+			Ord:       cardTypeOrd(cardType),
+			ReviewState: models.ReviewState{
+				State:      models.StateNew,
+				EaseFactor: 2.5,
+				Interval:   1,
+			},
+		}
+		cards = append(cards, card)
+	}
+
+	if len(cards) == 0 {
+		return nil
+	}
+	return tx.Create(&cards).Error
+}
+
+// ensureCards 为当前学习者补齐所有单词缺少的卡片
+func (s *WordService) ensureCards() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var cards []models.Card
+		if err := s.profileCards(tx).Select("word_id", "card_type").Find(&cards).Error; err != nil {
+			return err
+		}
+		existing := make(map[int][]string)
+		for _, card := range cards {
+			existing[card.WordID] = append(existing[card.WordID], card.CardType)
+		}
+
+		var words []models.Word
+		if err := tx.Find(&words).Error; err != nil {
+			return err
+		}
+		for _, word := range words {
+			if err := s.ensureWordCards(tx, word, existing[word.ID]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetWordCards 获取当前学习者在单词上的所有卡片
+func (s *WordService) GetWordCards(wordID int) []models.Card {
+	var cards []models.Card
+	s.profileCards(s.db).Where("word_id = ?", wordID).Order("ord").Find(&cards)
+	return cards
+}
+
+// recognitionCardID 返回单词识别卡片的ID
+func (s *WordService) recognitionCardID(wordID int) (int, error) {
+	var card models.Card
+	err := s.profileCards(s.db).Select("id").
+		Where("word_id = ? AND card_type = ?", wordID, models.CardTypeRecognition).
+		First(&card).Error
+	return card.ID, err
+}
+
+// ReviewCard 更新卡片复习状态并写入复习记录
+// 同一单词的其他卡片会被埋藏到下一个学习日，避免同一天出现
+func (s *WordService) ReviewCard(cardID int, quality int, responseTime int64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var card models.Card
+		if err := s.profileCards(tx).Preload("Word").First(&card, cardID).Error; err != nil {
+			return err
+		}
+		if card.Word == nil {
+			return fmt.Errorf("word %d not found", card.WordID)
+		}
+
+		reviewLog := models.ReviewLog{
+			ProfileID:    s.profileID,
+			WordID:       card.WordID,
+			CardID:       card.ID,
+			State:        card.State,
+			Quality:      quality,
+			PrevInterval: card.Interval,
+			PrevEase:     card.EaseFactor,
+			ResponseTime: responseTime,
+			CardType:     card.CardType,
+		}
+
+		// 更新学习步骤和间隔重复参数，并计算下次复习时间
+		now := time.Now()
+		s.answer(&card.ReviewState, s.schedulerFor(tx, card.WordID), quality, now)
+		card.BuriedUntil = 0
+		if err := tx.Omit(clause.Associations).Save(&card).Error; err != nil {
+			return err
+		}
+
+		// 埋藏同词卡片
+		if err := s.profileCards(tx).
+			Where("word_id = ? AND id <> ?", card.WordID, card.ID).
+			Update("buried_until", s.dayStart(now).AddDate(0, 0, 1).Unix()).Error; err != nil {
+			return err
+		}
+
+		reviewLog.ReviewedAt = now.Unix()
+		reviewLog.NewInterval = card.Interval
+		reviewLog.NewEase = card.EaseFactor
+		return tx.Create(&reviewLog).Error
+	})
+}
+
+// fillCloze 为填空卡片生成题面
+func fillCloze(cards []models.Card) {
+	for i := range cards {
+		if cards[i].CardType == models.CardTypeCloze && cards[i].Word != nil {
+			cards[i].Cloze, _ = ClozeText(cards[i].Word.Example, cards[i].Word.Word)
+		}
+	}
+}
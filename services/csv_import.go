@@ -0,0 +1,333 @@
+package services
+
+import (
+	"WordMaster/models"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// csvPreviewRows 预览时返回的行数
+const csvPreviewRows = 10
+
+// csvFields 可以映射的字段，顺序即前端显示顺序
+var csvFields = []string{
+	models.CSVFieldWord, models.CSVFieldPhonetic, models.CSVFieldPOS, models.CSVFieldDefinition,
+	models.CSVFieldExample, models.CSVFieldTranslation, models.CSVFieldImageURL,
+	models.CSVFieldDifficulty, models.CSVFieldTags,
+}
+
+// csvHeaderAliases 各字段可以识别的表头名称(小写)，用于猜测列映射
+var csvHeaderAliases = map[string][]string{
+	models.CSVFieldWord:        {"word", "words", "term", "headword", "单词", "英文"},
+	models.CSVFieldPhonetic:    {"phonetic", "phonetics", "ipa", "音标"},
+	models.CSVFieldPOS:         {"pos", "part of speech", "词性"},
+	models.CSVFieldDefinition:  {"definition", "meaning", "description", "释义", "解释", "词义", "中文"},
+	models.CSVFieldExample:     {"example", "sentence", "例句"},
+	models.CSVFieldTranslation: {"translation", "例句翻译", "翻译"},
+	models.CSVFieldImageURL:    {"imageurl", "image url", "image", "图片"},
+	models.CSVFieldDifficulty:  {"difficulty", "难度"},
+	models.CSVFieldTags:        {"tags", "tag", "标签"},
+}
+
+// csvEncodings 支持的文件编码
+var csvEncodings = map[string]encoding.Encoding{
+	"utf-8":    unicode.UTF8BOM,
+	"utf-16le": unicode.UTF16(unicode.LittleEndian, unicode.UseBOM),
+	"utf-16be": unicode.UTF16(unicode.BigEndian, unicode.UseBOM),
+	"gbk":      simplifiedchinese.GB18030,
+}
+
+// PreviewCSV 读取CSV/TSV文件开头的几行，检测表头并给出建议的列映射
+// delimiter 和 encoding 为空时自动检测
+func (s *WordService) PreviewCSV(filePath, delimiter, encoding string) (models.CSVPreview, error) {
+	text, comma, enc, err := readCSVText(filePath, delimiter, encoding)
+	if err != nil {
+		return models.CSVPreview{}, err
+	}
+
+	preview := models.CSVPreview{
+		Delimiter: string(comma),
+		Encoding:  enc,
+		Rows:      [][]string{},
+		Fields:    csvFields,
+	}
+	reader := newCSVReader(text, comma)
+	width := 0
+	for len(preview.Rows) < csvPreviewRows {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		// 引号不规范的行不显示，导入时会在错误报告中列出
+		if err != nil {
+			continue
+		}
+		preview.Rows = append(preview.Rows, record)
+		width = max(width, len(record))
+	}
+	if len(preview.Rows) == 0 {
+		return preview, errors.New("file is empty")
+	}
+
+	preview.Columns, preview.HasHeader = guessColumns(preview.Rows[0], width)
+	return preview, nil
+}
+
+// ImportCSV 按列映射从CSV/TSV文件导入单词
+// 缺少单词或释义、难度无效等有问题的行记录在结果的 Errors 中，不影响其他行的导入
+func (s *WordService) ImportCSV(filePath string, options models.CSVImportOptions) (models.ImportResult, error) {
+	if !slices.Contains(options.Columns, models.CSVFieldWord) {
+		return models.ImportResult{}, errors.New("no column is mapped to word")
+	}
+	for i, field := range options.Columns {
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(csvFields, field) {
+			return models.ImportResult{}, fmt.Errorf("unknown field '%s'", field)
+		}
+		if field != models.CSVFieldTags && slices.Contains(options.Columns[i+1:], field) {
+			return models.ImportResult{}, fmt.Errorf("field '%s' is mapped to more than one column", field)
+		}
+	}
+
+	text, comma, _, err := readCSVText(filePath, options.Delimiter, options.Encoding)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+
+	var words []models.Word
+	rowErrors := []models.RowError{}
+	reader := newCSVReader(text, comma)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, models.RowError{Row: parseErr.StartLine, Message: parseErr.Err.Error()})
+				continue
+			}
+			return models.ImportResult{}, err
+		}
+		row, _ := reader.FieldPos(0)
+		if first && options.HasHeader {
+			continue
+		}
+
+		word, err := csvRowWord(record, options.Columns)
+		if err != nil {
+			rowErrors = append(rowErrors, models.RowError{Row: row, Word: word.Word, Message: err.Error()})
+			continue
+		}
+		words = append(words, word)
+	}
+
+	result, err := s.importWordList(words, options.DeckID, options.MergeForms)
+	result.Errors = rowErrors
+	return result, err
+}
+
+// csvRowWord 按列映射将一行转换为单词
+func csvRowWord(record []string, columns []string) (models.Word, error) {
+	var word models.Word
+	var pos string
+	var tags []string
+	for i, field := range columns {
+		if i >= len(record) {
+			break
+		}
+		value := strings.TrimSpace(record[i])
+		switch field {
+		case models.CSVFieldWord:
+			word.Word = normalizeWordText(value)
+		case models.CSVFieldPhonetic:
+			word.Phonetic = value
+		case models.CSVFieldPOS:
+			pos = value
+		case models.CSVFieldDefinition:
+			word.Definition = value
+		case models.CSVFieldExample:
+			word.Example = value
+		case models.CSVFieldTranslation:
+			word.Translation = value
+		case models.CSVFieldImageURL:
+			word.ImageURL = value
+		case models.CSVFieldDifficulty:
+			if value == "" {
+				continue
+			}
+			difficulty, err := strconv.Atoi(value)
+			if err != nil || difficulty < 1 || difficulty > 5 {
+				return word, fmt.Errorf("invalid difficulty '%s', expected 1-5", value)
+			}
+			word.Difficulty = difficulty
+		case models.CSVFieldTags:
+			tags = append(tags, strings.FieldsFunc(value, func(r rune) bool {
+				return r == ',' || r == ';' || r == '，' || r == '；' || r == ' ' || r == '\t'
+			})...)
+		}
+	}
+
+	if word.Word == "" {
+		return word, errors.New("missing word")
+	}
+	if word.Definition == "" {
+		return word, errors.New("missing definition")
+	}
+	// 有词性时直接生成义项，否则由释义和例句字段生成
+	if pos != "" {
+		sense := models.Sense{PartOfSpeech: pos, Definition: word.Definition}
+		if word.Example != "" || word.Translation != "" {
+			sense.Examples = []models.SenseExample{{Text: word.Example, Translation: word.Translation}}
+		}
+		word.Senses = []models.Sense{sense}
+	}
+	for _, tag := range tags {
+		word.Tags = append(word.Tags, models.Tag{Name: tag})
+	}
+	return word, nil
+}
+
+// readCSVText 读取文件并按编码转换为文本，返回文本、分隔符和编码
+// delimiter 和 encoding 为空时自动检测
+func readCSVText(filePath, delimiter, encoding string) (string, rune, string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", 0, "", err
+	}
+	if encoding == "" {
+		encoding = detectEncoding(data)
+	}
+	text, err := decodeText(data, encoding)
+	if err != nil {
+		return "", 0, "", err
+	}
+	if delimiter == "" {
+		return text, detectDelimiter(text), encoding, nil
+	}
+	comma, size := utf8.DecodeRuneInString(delimiter)
+	if size != len(delimiter) || comma == '"' || comma == '\r' || comma == '\n' {
+		return "", 0, "", fmt.Errorf("invalid delimiter '%s'", delimiter)
+	}
+	return text, comma, encoding, nil
+}
+
+// guessColumns 根据第一行猜测 width 列的列映射，第一行有能识别的表头时视为表头
+// 没有表头时假设第一列为单词、第二列为释义
+func guessColumns(first []string, width int) ([]string, bool) {
+	columns := make([]string, width)
+	hasHeader := false
+	for i, name := range first {
+		field := csvHeaderField(name)
+		if field == "" || (field != models.CSVFieldTags && slices.Contains(columns, field)) {
+			continue
+		}
+		columns[i] = field
+		hasHeader = true
+	}
+	if hasHeader {
+		return columns, true
+	}
+
+	columns[0] = models.CSVFieldWord
+	if len(columns) > 1 {
+		columns[1] = models.CSVFieldDefinition
+	}
+	return columns, false
+}
+
+// csvHeaderField 返回表头名称对应的字段，无法识别时返回空字符串
+func csvHeaderField(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for field, aliases := range csvHeaderAliases {
+		if slices.Contains(aliases, name) {
+			return field
+		}
+	}
+	return ""
+}
+
+// detectEncoding 检测文件编码：有BOM时按BOM判断，否则是合法的UTF-8时为UTF-8，其余视为GBK
+// (中文版Excel导出的CSV默认为GBK)
+func detectEncoding(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return "utf-8"
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return "utf-16le"
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return "utf-16be"
+	case utf8.Valid(data):
+		return "utf-8"
+	default:
+		return "gbk"
+	}
+}
+
+// decodeText 将文件内容按编码转换为文本，UTF-8和UTF-16的BOM会被去掉
+func decodeText(data []byte, enc string) (string, error) {
+	decoder, ok := csvEncodings[strings.ToLower(enc)]
+	if !ok {
+		return "", fmt.Errorf("unsupported encoding '%s'", enc)
+	}
+	text, err := decoder.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", err
+	}
+	return string(text), nil
+}
+
+// detectDelimiter 检测分隔符：依次尝试制表符、逗号、分号和竖线，
+// 选择开头几行列数一致且列数最多的分隔符，都不合适时使用逗号
+func detectDelimiter(text string) rune {
+	best, bestScore := ',', 0
+	for _, delimiter := range []rune{'\t', ',', ';', '|'} {
+		reader := newCSVReader(text, delimiter)
+		var counts []int
+		for attempts := 0; attempts < csvPreviewRows; attempts++ {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				continue
+			}
+			counts = append(counts, len(record))
+		}
+		if len(counts) == 0 || counts[0] < 2 {
+			continue
+		}
+		score := counts[0]
+		if !slices.ContainsFunc(counts, func(n int) bool { return n != counts[0] }) {
+			score += 100
+		}
+		if score > bestScore {
+			best, bestScore = delimiter, score
+		}
+	}
+	return best
+}
+
+// newCSVReader 创建允许各行列数不一致的CSV读取器
+// 引号不规范的行会返回错误，由调用方记录后继续读取下一行
+func newCSVReader(text string, delimiter rune) *csv.Reader {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	return reader
+}
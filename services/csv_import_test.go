@@ -0,0 +1,109 @@
+package services
+
+import (
+	"WordMaster/models"
+	"slices"
+	"testing"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	gbk, err := simplifiedchinese.GBK.NewEncoder().Bytes([]byte("apple,苹果"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{[]byte("apple,苹果"), "utf-8"},
+		{append([]byte{0xEF, 0xBB, 0xBF}, "apple,苹果"...), "utf-8"},
+		{[]byte{0xFF, 0xFE, 'a', 0, ',', 0, 'b', 0}, "utf-16le"},
+		{[]byte{0xFE, 0xFF, 0, 'a', 0, ',', 0, 'b'}, "utf-16be"},
+		{gbk, "gbk"},
+		{nil, "utf-8"},
+	}
+	for _, tt := range tests {
+		if got := detectEncoding(tt.data); got != tt.want {
+			t.Errorf("detectEncoding(% x) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	gbk, err := simplifiedchinese.GBK.NewEncoder().Bytes([]byte("apple,苹果"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		data    []byte
+		enc     string
+		want    string
+		wantErr bool
+	}{
+		{[]byte("apple,苹果"), "utf-8", "apple,苹果", false},
+		{append([]byte{0xEF, 0xBB, 0xBF}, "apple"...), "UTF-8", "apple", false},
+		{[]byte{0xFF, 0xFE, 'a', 0, ',', 0, 'b', 0}, "utf-16le", "a,b", false},
+		{[]byte{0xFE, 0xFF, 0, 'a', 0, ',', 0, 'b'}, "utf-16be", "a,b", false},
+		{gbk, "gbk", "apple,苹果", false},
+		{[]byte("apple"), "latin-1", "", true},
+	}
+	for _, tt := range tests {
+		got, err := decodeText(tt.data, tt.enc)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("decodeText(% x, %q) error = %v, wantErr %v", tt.data, tt.enc, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("decodeText(% x, %q) = %q, want %q", tt.data, tt.enc, got, tt.want)
+		}
+	}
+}
+
+func TestDetectDelimiter(t *testing.T) {
+	tests := []struct {
+		text string
+		want rune
+	}{
+		{"apple,苹果\nbook,书\n", ','},
+		{"apple\t苹果\nbook\t书\n", '\t'},
+		{"apple;苹果;n.\nbook;书;n.\n", ';'},
+		{"apple|苹果\nbook|书\n", '|'},
+		// 释义中的逗号不影响制表符分隔的文件
+		{"apple\t苹果, 苹果树\nbook\t书\n", '\t'},
+		{"apple;\"n. 苹果, 苹果树\"\nbook;n. 书\n", ';'},
+		{"apple\nbook\n", ','},
+		{"", ','},
+	}
+	for _, tt := range tests {
+		if got := detectDelimiter(tt.text); got != tt.want {
+			t.Errorf("detectDelimiter(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestGuessColumns(t *testing.T) {
+	tests := []struct {
+		first      []string
+		width      int
+		want       []string
+		wantHeader bool
+	}{
+		{[]string{"Word", " 释义 ", "Tags", "标签"}, 4, []string{
+			models.CSVFieldWord, models.CSVFieldDefinition, models.CSVFieldTags, models.CSVFieldTags,
+		}, true},
+		{[]string{"单词", "音标", "中文", "notes"}, 5, []string{
+			models.CSVFieldWord, models.CSVFieldPhonetic, models.CSVFieldDefinition, "", "",
+		}, true},
+		{[]string{"word", "term"}, 2, []string{models.CSVFieldWord, ""}, true},
+		{[]string{"apple", "苹果", "n."}, 3, []string{models.CSVFieldWord, models.CSVFieldDefinition, ""}, false},
+		{[]string{"apple"}, 1, []string{models.CSVFieldWord}, false},
+	}
+	for _, tt := range tests {
+		got, hasHeader := guessColumns(tt.first, tt.width)
+		if !slices.Equal(got, tt.want) || hasHeader != tt.wantHeader {
+			t.Errorf("guessColumns(%q, %d) = %q, %v, want %q, %v", tt.first, tt.width, got, hasHeader, tt.want, tt.wantHeader)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// dataEntries 数据目录中属于 WordMaster 的文件和目录，移动数据目录时只移动这些
+var dataEntries = []string{"words.db", "audio", "images", "backups"}
+
+// Close 关闭数据库，不会与正在进行的备份或恢复同时进行
+func (s *WordService) Close() error {
+	s.backupMu.Lock()
+	defer s.backupMu.Unlock()
+	if s.db == nil {
+		return nil
+	}
+	err := closeDB(s.db)
+	s.db = nil
+	return err
+}
+
+// Closed 返回数据库是否已关闭，恢复备份失败且无法重新打开数据库时为 true
+func (s *WordService) Closed() bool {
+	s.backupMu.Lock()
+	defer s.backupMu.Unlock()
+	return s.db == nil
+}
+
+// CopyDataDirectory 将数据目录中的数据复制到 target，数据库需要先关闭
+// target 中已有 WordMaster 数据时拒绝复制；复制失败时删除已复制的部分
+func CopyDataDirectory(source, target string) error {
+	for _, name := range dataEntries {
+		if _, err := os.Stat(filepath.Join(target, name)); err == nil {
+			return fmt.Errorf("'%s' already contains WordMaster data (%s)", target, name)
+		}
+	}
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return err
+	}
+
+	for _, name := range dataEntries {
+		err := copyTree(filepath.Join(source, name), filepath.Join(target, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			RemoveDataDirectory(target)
+			return fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// RemoveDataDirectory 删除目录中属于 WordMaster 的数据，目录因此为空时一并删除
+func RemoveDataDirectory(dir string) error {
+	for _, name := range dataEntries {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		os.Remove(filepath.Join(dir, "words.db"+suffix))
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+		return os.Remove(dir)
+	}
+	return nil
+}
+
+// copyTree 复制文件或目录
+func copyTree(source, target string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return copyFile(source, target)
+	}
+
+	return filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(target, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+		return copyFile(path, dest)
+	})
+}
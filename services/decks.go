@@ -0,0 +1,116 @@
+package services
+
+import (
+	"WordMaster/models"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// GetDecks 获取所有单词本及其单词数量
+func (s *WordService) GetDecks() []models.Deck {
+	var decks []models.Deck
+	s.db.Order("name").Find(&decks)
+
+	var counts []struct {
+		DeckID int
+		Count  int
+	}
+	s.db.Table("deck_words").Select("deck_id, COUNT(*) AS count").
+		Where("word_id NOT IN " + trashedWordIDs).Group("deck_id").Scan(&counts)
+	wordCounts := make(map[int]int, len(counts))
+	for _, c := range counts {
+		wordCounts[c.DeckID] = c.Count
+	}
+	for i := range decks {
+		decks[i].WordCount = wordCounts[decks[i].ID]
+	}
+	return decks
+}
+
+// CreateDeck 创建单词本
+func (s *WordService) CreateDeck(deck models.Deck) (models.Deck, error) {
+	deck.ID = 0
+	if err := validateDeck(&deck); err != nil {
+		return models.Deck{}, err
+	}
+	if err := s.db.Omit("Words").Create(&deck).Error; err != nil {
+		return models.Deck{}, err
+	}
+	return deck, nil
+}
+
+// UpdateDeck 更新单词本的名称、描述和调度算法
+func (s *WordService) UpdateDeck(deck models.Deck) error {
+	if err := validateDeck(&deck); err != nil {
+		return err
+	}
+	return s.db.Model(&models.Deck{ID: deck.ID}).
+		Select("name", "description", "scheduler").
+		Updates(&deck).Error
+}
+
+// DeleteDeck 删除单词本，单词本身保留
+func (s *WordService) DeleteDeck(id int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM deck_words WHERE deck_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Deck{}, id).Error
+	})
+}
+
+// AddWordsToDeck 将单词加入单词本
+func (s *WordService) AddWordsToDeck(deckID int, wordIDs []int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return addWordsToDeck(tx, deckID, wordIDs)
+	})
+}
+
+// RemoveWordsFromDeck 将单词移出单词本
+func (s *WordService) RemoveWordsFromDeck(deckID int, wordIDs []int) error {
+	if len(wordIDs) == 0 {
+		return nil
+	}
+	return s.db.Exec("DELETE FROM deck_words WHERE deck_id = ? AND word_id IN ?", deckID, wordIDs).Error
+}
+
+// addWordsToDeck 在事务中将单词加入单词本，已在单词本中的单词忽略
+func addWordsToDeck(tx *gorm.DB, deckID int, wordIDs []int) error {
+	var deck models.Deck
+	if err := tx.First(&deck, deckID).Error; err != nil {
+		return err
+	}
+	for _, wordID := range wordIDs {
+		if err := tx.Exec("INSERT OR IGNORE INTO deck_words (deck_id, word_id) VALUES (?, ?)", deckID, wordID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inDeck 将查询限制在单词本内，deckID为0时不限制
+// column 为查询中表示单词ID的列
+func inDeck(query *gorm.DB, deckID int, column string) *gorm.DB {
+	if deckID == 0 {
+		return query
+	}
+	return query.Where(column+" IN (SELECT word_id FROM deck_words WHERE deck_id = ?)", deckID)
+}
+
+// validateDeck 检查单词本名称和调度算法
+func validateDeck(deck *models.Deck) error {
+	deck.Name = strings.TrimSpace(deck.Name)
+	if deck.Name == "" {
+		return errors.New("deck name cannot be empty")
+	}
+	if deck.Scheduler != "" {
+		settings := models.DefaultStudySettings()
+		settings.Scheduler = deck.Scheduler
+		if _, err := NewScheduler(settings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"WordMaster/models"
+	"math"
+	"time"
+)
+
+// FSRS 遗忘曲线常数
+const (
+	fsrsDecay  = -0.5
+	fsrsFactor = 19.0 / 81.0
+)
+
+// fsrsDefaultWeights FSRS-4.5 默认参数
+var fsrsDefaultWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031,
+	1.6474, 0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+// FSRS 评分等级
+const (
+	fsrsAgain = 1
+	fsrsHard  = 2
+	fsrsGood  = 3
+	fsrsEasy  = 4
+)
+
+// FSRSScheduler 基于FSRS(稳定性/难度/可提取性)模型的调度器
+type FSRSScheduler struct {
+	DesiredRetention float64     // 目标记忆保持率
+	MaximumInterval  int         // 最大复习间隔(天)，0表示不限制
+	Weights          [17]float64 // 模型参数
+}
+
+// NewFSRSScheduler 创建使用默认参数的FSRS调度器
+func NewFSRSScheduler(desiredRetention float64, maximumInterval int) *FSRSScheduler {
+	if desiredRetention <= 0 || desiredRetention >= 1 {
+		desiredRetention = 0.9
+	}
+	return &FSRSScheduler{
+		DesiredRetention: desiredRetention,
+		MaximumInterval:  maximumInterval,
+		Weights:          fsrsDefaultWeights,
+	}
+}
+
+// Name 返回调度算法名称
+func (s *FSRSScheduler) Name() string {
+	return SchedulerFSRS
+}
+
+// Schedule 根据回答质量更新稳定性、难度和复习间隔
+func (s *FSRSScheduler) Schedule(state *models.ReviewState, quality int, now time.Time) {
+	rating := fsrsRating(quality)
+	w := s.Weights
+
+	if state.Stability <= 0 {
+		if state.ReviewCount > 0 && state.Interval > 0 {
+			// 由SM-2切换而来的单词，以当前间隔近似稳定性
+			state.Stability = float64(state.Interval)
+			state.MemoryDifficulty = s.initDifficulty(fsrsGood)
+		} else {
+			// 首次学习
+			state.Stability = math.Max(w[rating-1], 0.1)
+			state.MemoryDifficulty = s.initDifficulty(rating)
+			state.ReviewCount++
+			state.Interval = s.nextInterval(state.Stability)
+			return
+		}
+	}
+
+	elapsed := 0.0
+	if state.LastReviewed > 0 {
+		elapsed = math.Max(0, now.Sub(time.Unix(state.LastReviewed, 0)).Hours()/24)
+	}
+	r := Retrievability(elapsed, state.Stability)
+
+	state.MemoryDifficulty = s.nextDifficulty(state.MemoryDifficulty, rating)
+	if rating == fsrsAgain {
+		state.Stability = s.forgetStability(state.MemoryDifficulty, state.Stability, r)
+		state.ReviewCount = 0
+	} else {
+		state.Stability = s.recallStability(state.MemoryDifficulty, state.Stability, r, rating)
+		state.ReviewCount++
+	}
+	state.Interval = s.nextInterval(state.Stability)
+}
+
+// Retrievability 计算经过elapsedDays天后、稳定性为stability时的回忆概率
+func Retrievability(elapsedDays float64, stability float64) float64 {
+	if stability <= 0 {
+		return 0
+	}
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+// nextInterval 计算达到目标保持率所需的间隔(天)
+func (s *FSRSScheduler) nextInterval(stability float64) int {
+	interval := stability / fsrsFactor * (math.Pow(s.DesiredRetention, 1/fsrsDecay) - 1)
+	return capInterval(int(math.Round(interval)), s.MaximumInterval)
+}
+
+// initDifficulty 计算首次评分后的初始难度
+func (s *FSRSScheduler) initDifficulty(rating int) float64 {
+	w := s.Weights
+	return clampDifficulty(w[4] - float64(rating-3)*w[5])
+}
+
+// nextDifficulty 计算复习后的难度，并向初始难度均值回归
+func (s *FSRSScheduler) nextDifficulty(d float64, rating int) float64 {
+	w := s.Weights
+	next := d - w[6]*float64(rating-3)
+	return clampDifficulty(w[7]*s.initDifficulty(fsrsGood) + (1-w[7])*next)
+}
+
+// recallStability 计算成功回忆后的稳定性
+func (s *FSRSScheduler) recallStability(d, stability, r float64, rating int) float64 {
+	w := s.Weights
+	hardPenalty, easyBonus := 1.0, 1.0
+	if rating == fsrsHard {
+		hardPenalty = w[15]
+	}
+	if rating == fsrsEasy {
+		easyBonus = w[16]
+	}
+	return stability * (1 + math.Exp(w[8])*(11-d)*math.Pow(stability, -w[9])*
+		(math.Exp((1-r)*w[10])-1)*hardPenalty*easyBonus)
+}
+
+// forgetStability 计算遗忘后的稳定性
+func (s *FSRSScheduler) forgetStability(d, stability, r float64) float64 {
+	w := s.Weights
+	next := w[11] * math.Pow(d, -w[12]) * (math.Pow(stability+1, w[13]) - 1) * math.Exp((1-r)*w[14])
+	return math.Max(0.1, math.Min(next, stability))
+}
+
+// fsrsRating 将0-5的回答质量映射为FSRS的四级评分
+func fsrsRating(quality int) int {
+	switch {
+	case quality < 3:
+		return fsrsAgain
+	case quality == 3:
+		return fsrsHard
+	case quality == 4:
+		return fsrsGood
+	default:
+		return fsrsEasy
+	}
+}
+
+// clampDifficulty 将难度限制在 [1, 10] 之间
+func clampDifficulty(d float64) float64 {
+	return math.Max(1, math.Min(10, d))
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"WordMaster/models"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRetrievability(t *testing.T) {
+	tests := []struct {
+		elapsed, stability, want float64
+	}{
+		{0, 5, 1},
+		{5, 5, 0.9}, // 经过与稳定性相同的天数后回忆概率为90%
+		{10, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := Retrievability(tt.elapsed, tt.stability); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Retrievability(%v, %v) = %v, want %v", tt.elapsed, tt.stability, got, tt.want)
+		}
+	}
+}
+
+func TestFSRSFirstReview(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		quality      int
+		wantInterval int
+	}{
+		{1, 1}, // 稳定性 w[0] 不足一天，间隔至少为1天
+		{3, 1},
+		{4, 4},
+		{5, 14},
+	}
+	for _, tt := range tests {
+		state := models.ReviewState{EaseFactor: 2.5}
+		NewFSRSScheduler(0.9, 0).Schedule(&state, tt.quality, now)
+		if state.Interval != tt.wantInterval {
+			t.Errorf("quality %d: interval = %d, want %d", tt.quality, state.Interval, tt.wantInterval)
+		}
+		if state.MemoryDifficulty < 1 || state.MemoryDifficulty > 10 {
+			t.Errorf("quality %d: difficulty %v out of range", tt.quality, state.MemoryDifficulty)
+		}
+	}
+}
+
+func TestFSRSReview(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	scheduler := NewFSRSScheduler(0.9, 0)
+	state := models.ReviewState{}
+	scheduler.Schedule(&state, 4, now)
+
+	// 按时复习且记住后稳定性和间隔增加，遗忘后稳定性下降
+	now = now.Add(time.Duration(state.Interval) * 24 * time.Hour)
+	state.LastReviewed = now.Add(-time.Duration(state.Interval) * 24 * time.Hour).Unix()
+	recalled := state
+	scheduler.Schedule(&recalled, 4, now)
+	if recalled.Stability <= state.Stability || recalled.Interval <= state.Interval {
+		t.Errorf("recall: stability %v → %v, interval %d → %d", state.Stability, recalled.Stability, state.Interval, recalled.Interval)
+	}
+	forgotten := state
+	scheduler.Schedule(&forgotten, 1, now)
+	if forgotten.Stability >= state.Stability || forgotten.ReviewCount != 0 {
+		t.Errorf("lapse: stability %v → %v, review count %d", state.Stability, forgotten.Stability, forgotten.ReviewCount)
+	}
+
+	// 目标保持率越高间隔越短，间隔不超过最大间隔
+	high := NewFSRSScheduler(0.95, 0).nextInterval(30)
+	low := NewFSRSScheduler(0.8, 0).nextInterval(30)
+	if high >= 30 || low <= 30 {
+		t.Errorf("nextInterval(30) = %d at 0.95 and %d at 0.8", high, low)
+	}
+	if got := NewFSRSScheduler(0.8, 20).nextInterval(30); got != 20 {
+		t.Errorf("nextInterval with maximum 20 = %d", got)
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"WordMaster/models"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// fuzzRanges 不同间隔区间对应的扰动比例
+var fuzzRanges = []struct {
+	start, end, factor float64
+}{
+	{2.5, 7, 0.15},
+	{7, 20, 0.1},
+	{20, math.MaxFloat64, 0.05},
+}
+
+// fuzzBounds 返回间隔的扰动范围 [lo, hi]，间隔过短时不扰动
+func fuzzBounds(interval int, maximum int) (int, int) {
+	days := float64(interval)
+	if days < 2.5 {
+		return interval, interval
+	}
+
+	delta := 1.0
+	for _, r := range fuzzRanges {
+		delta += r.factor * math.Max(math.Min(days, r.end)-r.start, 0)
+	}
+
+	lo := max(int(math.Round(days-delta)), 2)
+	hi := int(math.Round(days + delta))
+	if maximum > 0 {
+		hi = min(hi, maximum)
+		lo = min(lo, hi)
+	}
+	return lo, hi
+}
+
+// fuzzInterval 在扰动范围内选择最终间隔，避免同批单词一直同时到期
+// 开启负载均衡时选择范围内到期单词最少的一天，否则随机选择
+func (s *WordService) fuzzInterval(interval int, now time.Time) int {
+	if !s.settings.Fuzz && !s.settings.LoadBalance {
+		return interval
+	}
+
+	lo, hi := fuzzBounds(interval, s.settings.MaximumInterval)
+	if lo == hi {
+		return interval
+	}
+	if !s.settings.LoadBalance {
+		return lo + rand.Intn(hi-lo+1)
+	}
+
+	load := s.dueLoad(now, lo, hi)
+	best := interval
+	for days := lo; days <= hi; days++ {
+		if load[days] < load[best] ||
+			(load[days] == load[best] && abs(days-interval) < abs(best-interval)) {
+			best = days
+		}
+	}
+	return best
+}
+
+// dueLoad 统计从今天起第lo到第hi天每天到期的复习数量
+func (s *WordService) dueLoad(now time.Time, lo, hi int) map[int]int {
+	today := s.dayStart(now)
+	var dues []int64
+	s.profileCards(s.db).
+		Where("suspended = ? AND state = ? AND next_review >= ? AND next_review < ?", false, models.StateReview,
+			today.AddDate(0, 0, lo).Unix(), today.AddDate(0, 0, hi+1).Unix()).
+		Pluck("next_review", &dues)
+
+	load := make(map[int]int, hi-lo+1)
+	for _, due := range dues {
+		days := int(time.Unix(due, 0).Sub(today).Hours() / 24)
+		load[days]++
+	}
+	return load
+}
+
+// abs 返回整数的绝对值
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,25 @@
+package services
+
+import "testing"
+
+func TestFuzzBounds(t *testing.T) {
+	tests := []struct {
+		interval, maximum int
+		wantLo, wantHi    int
+	}{
+		{0, 0, 0, 0},
+		{1, 0, 1, 1},
+		{2, 0, 2, 2},
+		{3, 0, 2, 4},
+		{10, 0, 8, 12},
+		{100, 0, 93, 107},
+		{100, 100, 93, 100},
+		{10, 5, 5, 5},
+	}
+	for _, tt := range tests {
+		lo, hi := fuzzBounds(tt.interval, tt.maximum)
+		if lo != tt.wantLo || hi != tt.wantHi {
+			t.Errorf("fuzzBounds(%d, %d) = (%d, %d), want (%d, %d)", tt.interval, tt.maximum, lo, hi, tt.wantLo, tt.wantHi)
+		}
+	}
+}
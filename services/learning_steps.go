@@ -0,0 +1,121 @@
+package services
+
+import (
+	"WordMaster/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSteps 解析学习步骤字符串，如 "1m 10m 1h 1d"
+// 步骤之间可以用空格或逗号分隔，支持 s/m/h/d 单位
+func ParseSteps(steps string) ([]time.Duration, error) {
+	fields := strings.FieldsFunc(steps, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '，'
+	})
+
+	result := make([]time.Duration, 0, len(fields))
+	for _, field := range fields {
+		var step time.Duration
+		if days, ok := strings.CutSuffix(field, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err != nil {
+				return nil, fmt.Errorf("invalid step '%s'", field)
+			}
+			step = time.Duration(n) * 24 * time.Hour
+		} else {
+			d, err := time.ParseDuration(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid step '%s'", field)
+			}
+			step = d
+		}
+		if step <= 0 {
+			return nil, fmt.Errorf("invalid step '%s'", field)
+		}
+		result = append(result, step)
+	}
+	return result, nil
+}
+
+// answer 处理一次作答：新词和遗忘的单词先走分钟级学习步骤，
+// 完成所有步骤后再按调度器计算的天级间隔安排复习
+func (s *WordService) answer(state *models.ReviewState, scheduler Scheduler, quality int, now time.Time) {
+	learningSteps, _ := ParseSteps(s.settings.LearningSteps)
+	relearningSteps, _ := ParseSteps(s.settings.RelearningSteps)
+
+	switch state.State {
+	case models.StateLearning:
+		s.advanceStep(state, learningSteps, quality, now)
+	case models.StateRelearning:
+		s.advanceStep(state, relearningSteps, quality, now)
+	case models.StateReview:
+		scheduler.Schedule(state, quality, now)
+		if quality < 3 {
+			s.recordLapse(state)
+		}
+		if quality < 3 && len(relearningSteps) > 0 {
+			state.State = models.StateRelearning
+			state.Step = 0
+			state.NextReview = now.Add(relearningSteps[0]).Unix()
+		} else {
+			s.graduate(state, now)
+		}
+	default:
+		// 新词首次作答：由调度器初始化记忆参数，得到的间隔在完成学习步骤后使用
+		scheduler.Schedule(state, quality, now)
+		if quality >= 5 || len(learningSteps) == 0 {
+			s.graduateEasy(state, quality, now)
+		} else {
+			state.State = models.StateLearning
+			state.Step = 0
+			s.advanceStep(state, learningSteps, quality, now)
+		}
+	}
+
+	state.LastReviewed = now.Unix()
+}
+
+// advanceStep 在学习步骤中前进、重复或退回第一步
+func (s *WordService) advanceStep(state *models.ReviewState, steps []time.Duration, quality int, now time.Time) {
+	switch {
+	case len(steps) == 0 || quality >= 5:
+		s.graduateEasy(state, quality, now)
+		return
+	case quality < 3:
+		state.Step = 0
+	case quality == 3:
+		// 保持当前步骤
+	default:
+		state.Step++
+	}
+
+	if state.Step >= len(steps) {
+		s.graduate(state, now)
+		return
+	}
+	state.NextReview = now.Add(steps[state.Step]).Unix()
+}
+
+// graduateEasy 以"非常简单"结束学习阶段，间隔不少于EasyInterval
+func (s *WordService) graduateEasy(state *models.ReviewState, quality int, now time.Time) {
+	if quality >= 5 && state.Interval < s.settings.EasyInterval {
+		state.Interval = s.settings.EasyInterval
+	}
+	s.graduate(state, now)
+}
+
+// graduate 结束(重新)学习阶段，按(加入扰动后的)天级间隔安排下次复习
+func (s *WordService) graduate(state *models.ReviewState, now time.Time) {
+	state.State = models.StateReview
+	state.Step = 0
+	state.Interval = s.fuzzInterval(capInterval(state.Interval, s.settings.MaximumInterval), now)
+	state.NextReview = now.Add(time.Duration(state.Interval) * 24 * time.Hour).Unix()
+}
+
+// learnAheadCutoff 返回学习中卡片提前取回的截止时间戳
+// 只在没有其他到期卡片时使用，否则分钟级的学习步骤会被提前窗口覆盖
+func (s *WordService) learnAheadCutoff(now time.Time) int64 {
+	return now.Add(time.Duration(s.settings.LearnAheadLimit) * time.Minute).Unix()
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseSteps(t *testing.T) {
+	tests := []struct {
+		steps   string
+		want    []time.Duration
+		wantErr bool
+	}{
+		{"1m 10m", []time.Duration{time.Minute, 10 * time.Minute}, false},
+		{"1m,10m，1h 2d", []time.Duration{time.Minute, 10 * time.Minute, time.Hour, 48 * time.Hour}, false},
+		{"30s", []time.Duration{30 * time.Second}, false},
+		{"", []time.Duration{}, false},
+		{"  ", []time.Duration{}, false},
+		{"10", nil, true},
+		{"1x", nil, true},
+		{"0m", nil, true},
+		{"-5m", nil, true},
+		{"1.5d", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSteps(tt.steps)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSteps(%q) error = %v, wantErr %v", tt.steps, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseSteps(%q) = %v, want %v", tt.steps, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"WordMaster/models"
+)
+
+// recordLapse 记录一次遗忘，超过阈值时标记为顽固词
+func (s *WordService) recordLapse(state *models.ReviewState) {
+	state.Lapses++
+
+	threshold := s.settings.LeechThreshold
+	if threshold <= 0 || state.Lapses < threshold {
+		return
+	}
+
+	state.Leech = true
+	if s.settings.LeechAction == models.LeechActionSuspend {
+		state.Suspended = true
+	}
+}
+
+// GetLeeches 获取所有顽固卡片及其单词，按遗忘次数倒序
+func (s *WordService) GetLeeches() []models.Card {
+	var cards []models.Card
+	s.profileCards(s.db).Preload("Word").Where("leech = ?", true).Order("lapses DESC").Find(&cards)
+	return cards
+}
+
+// SetWordSuspended 暂停或恢复单词所有卡片的学习
+// 恢复顽固词时清除顽固词标记，遗忘次数重新计数
+func (s *WordService) SetWordSuspended(id int, suspended bool) error {
+	updates := map[string]interface{}{"suspended": suspended}
+	if !suspended {
+		updates["leech"] = false
+		updates["lapses"] = 0
+	}
+	return s.profileCards(s.db).Where("word_id = ?", id).Updates(updates).Error
+}
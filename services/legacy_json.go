@@ -0,0 +1,121 @@
+package services
+
+import (
+	"WordMaster/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// legacyJSONName 早期版本保存单词和学习进度的JSON文件，位于数据目录下
+const legacyJSONName = "words.json"
+
+// legacyJSONWord 旧版 words.json 中的单词，学习进度与词条保存在一起
+type legacyJSONWord struct {
+	models.Word
+	models.ReviewState
+	Learned  bool `json:"learned"`
+	Mastered bool `json:"mastered"`
+}
+
+// ImportLegacyWords 将数据目录中旧版的 words.json 导入数据库并保留学习进度，
+// 导入后将原文件移到 backups 目录归档。没有旧文件时返回 nil。
+// 已在词库中的单词不会重复添加，其识别卡片还未学习时使用旧文件中的进度；
+// 导入中途失败时不归档，下次启动时重新导入
+func (s *WordService) ImportLegacyWords() (*models.LegacyImportResult, error) {
+	legacyPath := filepath.Join(s.dataDir, legacyJSONName)
+	data, err := os.ReadFile(legacyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	words, err := parseLegacyWords(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", legacyJSONName, err)
+	}
+
+	if _, err := s.backup(models.BackupReasonImport); err != nil {
+		return nil, err
+	}
+
+	result := &models.LegacyImportResult{}
+	for _, legacy := range words {
+		if normalizeWordText(legacy.Word.Word) == "" {
+			result.Skipped++
+			continue
+		}
+		progress, err := s.importLegacyWord(legacy, result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import '%s': %w", legacy.Word.Word, err)
+		}
+		if progress {
+			result.Progress++
+		}
+	}
+
+	if err := os.MkdirAll(backupDir(s.dataDir), 0755); err != nil {
+		return nil, err
+	}
+	archive := uniqueBackupPath(backupDir(s.dataDir), "words-"+time.Now().Format("20060102-150405"), ".json")
+	if err := os.Rename(legacyPath, archive); err != nil {
+		return nil, fmt.Errorf("failed to archive %s: %w", legacyJSONName, err)
+	}
+	result.Archive = archive
+	return result, nil
+}
+
+// parseLegacyWords 解析旧版单词文件，支持 {"words": [...]} 和单词数组两种格式
+func parseLegacyWords(data []byte) ([]legacyJSONWord, error) {
+	var list struct {
+		Words []legacyJSONWord `json:"words"`
+	}
+	if err := json.Unmarshal(data, &list); err == nil {
+		return list.Words, nil
+	}
+	var words []legacyJSONWord
+	err := json.Unmarshal(data, &words)
+	return words, err
+}
+
+// importLegacyWord 导入一个旧版单词，返回是否保留了学习进度
+func (s *WordService) importLegacyWord(legacy legacyJSONWord, result *models.LegacyImportResult) (bool, error) {
+	state := legacyReviewState(legacy.ReviewState, legacy.Learned || legacy.Mastered)
+	if state.EaseFactor == 0 {
+		state.EaseFactor = 2.5
+	}
+	hasProgress := state.State != models.StateNew
+
+	word := legacy.Word
+	word.Cards = nil
+	if hasProgress {
+		word.Cards = []models.Card{{CardType: models.CardTypeRecognition, ReviewState: state}}
+	}
+	added, err := s.addWord(word, models.RevisionSourceImport)
+	if err == nil {
+		result.Added++
+		return hasProgress, nil
+	}
+	if !errors.Is(err, errWordExists) {
+		return false, err
+	}
+
+	// 单词已在词库中(如上次导入中途失败)，只在还未学习时使用旧进度
+	result.Existing++
+	if !hasProgress {
+		return false, nil
+	}
+	var card models.Card
+	err = s.profileCards(s.db).
+		Where("word_id = ? AND card_type = ? AND state = ?", added.ID, models.CardTypeRecognition, models.StateNew).
+		Limit(1).Find(&card).Error
+	if err != nil || card.ID == 0 {
+		return false, err
+	}
+	card.ReviewState = state
+	return true, s.db.Save(&card).Error
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"WordMaster/models"
+
+	"gorm.io/gorm"
+)
+
+// legacyProgressColumns 旧版words表中保存学习进度的列
+var legacyProgressColumns = []string{
+	"state", "step", "last_reviewed", "next_review", "review_count", "ease_factor", "interval",
+	"stability", "memory_difficulty", "lapses", "leech", "suspended", "learned", "mastered",
+}
+
+// legacyWordProgress 旧版words表中与词条保存在一起的学习进度
+type legacyWordProgress struct {
+	ID int
+	models.ReviewState
+	Learned  bool
+	Mastered bool
+}
+
+// cardV1 第1版的卡片表，迁移按这一版本的列写入卡片
+type cardV1 struct {
+	ID               int
+	WordID           int
+	CardType         string
+	Ord              int
+	State            string
+	Step             int
+	LastReviewed     int64
+	NextReview       int64
+	ReviewCount      int
+	EaseFactor       float64
+	Interval         int
+	Stability        float64
+	MemoryDifficulty float64
+	Lapses           int
+	Leech            bool
+	Suspended        bool
+	BuriedUntil      int64
+}
+
+// TableName 指定卡片表名
+func (cardV1) TableName() string {
+	return "cards"
+}
+
+// migrateLegacyProgress 将旧版words表中的学习进度迁移到识别卡片，并删除这些列
+func migrateLegacyProgress(tx *gorm.DB) error {
+	var columns []string
+	for _, column := range legacyProgressColumns {
+		if tx.Migrator().HasColumn("words", column) {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	var rows []legacyWordProgress
+	if err := tx.Table("words").Select(append([]string{"id"}, columns...)).Find(&rows).Error; err != nil {
+		return err
+	}
+
+	var migrated []int
+	if err := tx.Table("cards").Where("card_type = ?", "recognition").
+		Pluck("word_id", &migrated).Error; err != nil {
+		return err
+	}
+	hasCard := make(map[int]bool, len(migrated))
+	for _, wordID := range migrated {
+		hasCard[wordID] = true
+	}
+
+	var cards []cardV1
+	for _, row := range rows {
+		if hasCard[row.ID] {
+			continue
+		}
+		state := legacyReviewState(row.ReviewState, row.Learned || row.Mastered)
+		cards = append(cards, cardV1{
+			WordID:           row.ID,
+			CardType:         "recognition",
+			State:            state.State,
+			Step:             state.Step,
+			LastReviewed:     state.LastReviewed,
+			NextReview:       state.NextReview,
+			ReviewCount:      state.ReviewCount,
+			EaseFactor:       state.EaseFactor,
+			Interval:         state.Interval,
+			Stability:        state.Stability,
+			MemoryDifficulty: state.MemoryDifficulty,
+			Lapses:           state.Lapses,
+			Leech:            state.Leech,
+			Suspended:        state.Suspended,
+		})
+	}
+	if len(cards) > 0 {
+		if err := tx.CreateInBatches(&cards, 500).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec("DROP INDEX IF EXISTS idx_words_state").Error; err != nil {
+		return err
+	}
+	for _, column := range columns {
+		if err := tx.Exec("ALTER TABLE words DROP COLUMN `" + column + "`").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// legacyReviewState 补全旧版学习进度中的卡片状态
+// 旧版SM-2答错时会将复习次数清零，因此除复习次数外，标记为已学习(已掌握)或简易度、间隔
+// 不是初始值的单词也视为学习过：复习次数不为0的视为复习中，为0的说明上次答错，视为重新学习
+func legacyReviewState(state models.ReviewState, learned bool) models.ReviewState {
+	if state.State != "" {
+		return state
+	}
+	reviewed := state.ReviewCount > 0 || learned ||
+		(state.EaseFactor != 0 && state.EaseFactor != 2.5) || state.Interval > 1
+	switch {
+	case !reviewed:
+		state.State = models.StateNew
+	case state.ReviewCount > 0:
+		state.State = models.StateReview
+	default:
+		state.State = models.StateRelearning
+		state.Lapses = max(state.Lapses, 1)
+	}
+	return state
+}
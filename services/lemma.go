@@ -0,0 +1,249 @@
+package services
+
+import (
+	"WordMaster/models"
+	_ "embed"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//go:embed irregular_forms.txt
+var irregularFormsData string
+
+// irregularLemmas 不规则变形到原形的映射，一个变形可能对应多个原形
+var irregularLemmas = parseIrregularForms(irregularFormsData)
+
+// parseIrregularForms 解析不规则变形表，每行为原形及其变形，# 开头的行为注释
+func parseIrregularForms(data string) map[string][]string {
+	lemmas := make(map[string][]string)
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		lemma := strings.ToLower(fields[0])
+		for _, form := range fields[1:] {
+			form = strings.ToLower(form)
+			if !slices.Contains(lemmas[form], lemma) {
+				lemmas[form] = append(lemmas[form], lemma)
+			}
+		}
+	}
+	return lemmas
+}
+
+// 判断规则变形时使用的词性分类
+const (
+	posNoun      = "noun"
+	posVerb      = "verb"
+	posAdjective = "adjective"
+)
+
+// posAbbreviations 词性标注(小写，不含句点)到词性分类的映射，其他词性(如 aux.、conj.)不参与判断
+var posAbbreviations = map[string]string{
+	"n": posNoun, "noun": posNoun,
+	"v": posVerb, "vt": posVerb, "vi": posVerb, "verb": posVerb,
+	"a": posAdjective, "adj": posAdjective, "adjective": posAdjective,
+}
+
+// definitionPOSPattern 释义开头的词性标注，如 "v. 影响"、"vt.&vi. 打开"、"n./v. 帮助"
+var definitionPOSPattern = regexp.MustCompile(`^\s*((?:[A-Za-z]+\.\s*[&/,]?\s*)+)`)
+
+// wordClasses 返回单词义项和释义中标注的词性分类
+func wordClasses(word models.Word) []string {
+	var classes []string
+	add := func(pos string) {
+		for _, token := range strings.FieldsFunc(strings.ToLower(pos), func(r rune) bool { return r < 'a' || r > 'z' }) {
+			if class, ok := posAbbreviations[token]; ok && !slices.Contains(classes, class) {
+				classes = append(classes, class)
+			}
+		}
+	}
+	for _, sense := range word.Senses {
+		add(sense.PartOfSpeech)
+	}
+	// 释义汇总为 "词性 释义; 词性 释义"
+	for _, part := range strings.FieldsFunc(word.Definition, func(r rune) bool { return r == ';' || r == '；' }) {
+		if match := definitionPOSPattern.FindStringSubmatch(part); match != nil {
+			add(match[1])
+		}
+	}
+	return classes
+}
+
+// lemmaCandidate 单词可能的原形
+type lemmaCandidate struct {
+	lemma   string
+	classes []string // 原形需具有其中一种词性，为空表示来自不规则变形表，不检查词性
+}
+
+// 规则变形要求原形具有的词性：复数或第三人称单数、动词过去式和分词、形容词比较级和最高级
+var (
+	pluralClasses     = []string{posNoun, posVerb}
+	verbFormClasses   = []string{posVerb}
+	comparisonClasses = []string{posAdjective}
+)
+
+// lemmaCandidates 返回单词可能的原形，按可能性排序，不包含单词本身
+// 先查不规则变形表，再按规则变形推断(runs/running/stopped/studies/bigger 等)。
+// 推断出的原形不一定是真实的单词，需要用 matches 与词库中的单词比对；
+// 全部大写的缩写(如 AIDS)不视为变形
+func lemmaCandidates(word string) []lemmaCandidate {
+	word = strings.TrimSpace(word)
+	if len(word) >= 2 && word == strings.ToUpper(word) {
+		return nil
+	}
+	word = strings.ToLower(word)
+	for _, r := range word {
+		if r < 'a' || r > 'z' {
+			return nil
+		}
+	}
+
+	var candidates []lemmaCandidate
+	add := func(lemma string, classes []string) {
+		if len(lemma) < 2 || lemma == word ||
+			slices.ContainsFunc(candidates, func(c lemmaCandidate) bool { return c.lemma == lemma }) {
+			return
+		}
+		candidates = append(candidates, lemmaCandidate{lemma: lemma, classes: classes})
+	}
+	for _, lemma := range irregularLemmas[word] {
+		add(lemma, nil)
+	}
+
+	// stem 去掉词尾后的词干，依次尝试：词干、词干+e、去掉重复的辅音字母
+	addStem := func(stem string, classes []string) {
+		add(stem, classes)
+		add(stem+"e", classes)
+		if n := len(stem); n >= 3 && stem[n-1] == stem[n-2] && !strings.ContainsRune("aeiou", rune(stem[n-1])) {
+			add(stem[:n-1], classes)
+		}
+	}
+	n := len(word)
+	switch {
+	case strings.HasSuffix(word, "ies") && n > 4:
+		add(word[:n-3]+"y", pluralClasses)
+	case strings.HasSuffix(word, "es") && n > 3:
+		add(word[:n-2], pluralClasses)
+		add(word[:n-1], pluralClasses)
+	case strings.HasSuffix(word, "s") && n > 3 &&
+		!strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		add(word[:n-1], pluralClasses)
+	case strings.HasSuffix(word, "ied") && n > 4:
+		add(word[:n-3]+"y", verbFormClasses)
+	case strings.HasSuffix(word, "ed") && n > 4:
+		addStem(word[:n-2], verbFormClasses)
+	case strings.HasSuffix(word, "ing") && n > 5:
+		addStem(word[:n-3], verbFormClasses)
+	case strings.HasSuffix(word, "iest") && n > 5:
+		add(word[:n-4]+"y", comparisonClasses)
+	case strings.HasSuffix(word, "est") && n > 5:
+		addStem(word[:n-3], comparisonClasses)
+	case strings.HasSuffix(word, "ier") && n > 4:
+		add(word[:n-3]+"y", comparisonClasses)
+	case strings.HasSuffix(word, "er") && n > 4:
+		addStem(word[:n-2], comparisonClasses)
+	}
+	return candidates
+}
+
+// matches 判断单词能否作为该候选原形：不规则变形直接匹配，规则变形要求原形具有相应的词性，
+// 避免 butter → but、news → new 之类的误判。没有标注词性的单词不作为规则变形的原形
+func (c lemmaCandidate) matches(lemma models.Word) bool {
+	if wordKey(lemma.Word) != c.lemma {
+		return false
+	}
+	if len(c.classes) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(wordClasses(lemma), func(class string) bool {
+		return slices.Contains(c.classes, class)
+	})
+}
+
+// findLemmas 在词库中查找单词的原形，按可能性排序
+func findLemmas(db *gorm.DB, word string) ([]models.Word, error) {
+	candidates := lemmaCandidates(word)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		keys[i] = candidate.lemma
+	}
+	var words []models.Word
+	if err := db.Where("word_key IN ?", keys).Find(&words).Error; err != nil {
+		return nil, err
+	}
+
+	var lemmas []models.Word
+	for _, candidate := range candidates {
+		for _, word := range words {
+			if candidate.matches(word) {
+				lemmas = append(lemmas, word)
+			}
+		}
+	}
+	return lemmas, nil
+}
+
+// LookupWord 按单词的任意形式查找词库中的单词，如 ran 找到 run
+// 单词本身在词库中时直接返回；找不到时返回ID为0的空单词
+func (s *WordService) LookupWord(form string) (models.Word, error) {
+	var word models.Word
+	err := s.db.Where("word_key = ?", wordKey(form)).Limit(1).Find(&word).Error
+	if err != nil {
+		return models.Word{}, err
+	}
+	if word.ID == 0 {
+		lemmas, err := findLemmas(s.db, form)
+		if err != nil {
+			return models.Word{}, err
+		}
+		if len(lemmas) == 0 {
+			return models.Word{}, nil
+		}
+		word = lemmas[0]
+	}
+	return s.GetWordByID(word.ID)
+}
+
+// importLemma 返回导入的单词在词库或导入文件中的原形，不是变形时返回空字符串
+// inFile 为导入文件中的单词，以 wordKey 为键
+func importLemma(db *gorm.DB, word string, inFile map[string]models.Word) (string, error) {
+	lemmas, err := findLemmas(db, word)
+	if err != nil {
+		return "", err
+	}
+	for _, candidate := range lemmaCandidates(word) {
+		if fileWord, ok := inFile[candidate.lemma]; ok && candidate.matches(fileWord) {
+			return candidate.lemma, nil
+		}
+		for _, lemma := range lemmas {
+			if lemma.Key == candidate.lemma {
+				return lemma.Word, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// searchTermForms 为每个搜索词加上词库中其原形的单词，搜索 ran 时也能找到 run
+func (s *WordService) searchTermForms(terms []string) ([][]string, error) {
+	groups := make([][]string, len(terms))
+	for i, term := range terms {
+		groups[i] = []string{term}
+		lemmas, err := findLemmas(s.db, term)
+		if err != nil {
+			return nil, err
+		}
+		for _, lemma := range lemmas {
+			groups[i] = append(groups[i], lemma.Word)
+		}
+	}
+	return groups, nil
+}
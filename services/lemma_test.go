@@ -0,0 +1,114 @@
+package services
+
+import (
+	"WordMaster/models"
+	"slices"
+	"testing"
+)
+
+func TestLemmaCandidates(t *testing.T) {
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"ran", []string{"run"}},
+		{"leaves", []string{"leave", "leaf", "leav"}},
+		{"runs", []string{"run"}},
+		{"running", []string{"run", "runn", "runne"}},
+		{"hopping", []string{"hopp", "hoppe", "hop"}},
+		{"stopped", []string{"stopp", "stoppe", "stop"}},
+		{"studies", []string{"study"}},
+		{"bigger", []string{"bigg", "bigge", "big"}},
+		{"happiest", []string{"happy"}},
+		{"Running", []string{"run", "runn", "runne"}},
+		{"AIDS", nil},
+		{"USA", nil},
+		{"glass", nil},
+		{"run", nil},
+		{"e-mail", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, candidate := range lemmaCandidates(tt.word) {
+			got = append(got, candidate.lemma)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("lemmaCandidates(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+// isFormOf 判断 word 是否为词库单词 lemma 的变形
+func isFormOf(word string, lemma models.Word) bool {
+	for _, candidate := range lemmaCandidates(word) {
+		if candidate.matches(lemma) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLemmaCandidatesMatch(t *testing.T) {
+	tests := []struct {
+		word  string
+		lemma models.Word
+		want  bool
+	}{
+		// 规则变形需要原形标注了相应的词性
+		{"jumps", models.Word{Word: "jump", Definition: "v. 跳"}, true},
+		{"running", models.Word{Word: "run", Definition: "n. 跑步; v. 跑"}, true},
+		{"stopped", models.Word{Word: "stop", Definition: "vt.&vi. 停止"}, true},
+		{"studies", models.Word{Word: "study", Definition: "n./v. 学习"}, true},
+		{"apples", models.Word{Word: "apple", Definition: "n.苹果"}, true},
+		{"bigger", models.Word{Word: "big", Definition: "adj. 大的"}, true},
+		{"liked", models.Word{Word: "like", Senses: []models.Sense{{PartOfSpeech: "verb", Definition: "喜欢"}}}, true},
+		{"jumps", models.Word{Word: "jump"}, false},
+		{"bigger", models.Word{Word: "big", Definition: "大的"}, false},
+
+		// 不规则变形不检查词性
+		{"ran", models.Word{Word: "run"}, true},
+		{"went", models.Word{Word: "go", Definition: "v. 去"}, true},
+
+		// 拼写相同但不是变形的单词
+		{"butter", models.Word{Word: "but", Definition: "conj. 但是"}, false},
+		{"butter", models.Word{Word: "but"}, false},
+		{"forest", models.Word{Word: "for", Definition: "prep. 为了"}, false},
+		{"shoulder", models.Word{Word: "should", Definition: "aux. 应该"}, false},
+		{"news", models.Word{Word: "new", Definition: "adj. 新的"}, false},
+		{"number", models.Word{Word: "numb"}, false},
+		{"corner", models.Word{Word: "corn", Definition: "n. 玉米"}, false},
+		{"hammer", models.Word{Word: "ham", Definition: "n. 火腿"}, false},
+		{"sheer", models.Word{Word: "she", Definition: "pron. 她"}, false},
+		{"offer", models.Word{Word: "off", Definition: "adv. 离开; prep. 离开"}, false},
+		{"upper", models.Word{Word: "up", Definition: "adv. 向上"}, false},
+		{"manner", models.Word{Word: "man", Definition: "n. 男人"}, false},
+		{"digest", models.Word{Word: "dig", Definition: "v. 挖"}, false},
+		{"career", models.Word{Word: "care", Definition: "n./v. 关心"}, false},
+		{"AIDS", models.Word{Word: "aid", Definition: "n./v. 帮助"}, false},
+	}
+	for _, tt := range tests {
+		if got := isFormOf(tt.word, tt.lemma); got != tt.want {
+			t.Errorf("isFormOf(%q, %q %q) = %v, want %v", tt.word, tt.lemma.Word, tt.lemma.Definition, got, tt.want)
+		}
+	}
+}
+
+func TestWordClasses(t *testing.T) {
+	tests := []struct {
+		word models.Word
+		want []string
+	}{
+		{models.Word{Definition: "v. 影响"}, []string{posVerb}},
+		{models.Word{Definition: "n. 书; v. 预订"}, []string{posNoun, posVerb}},
+		{models.Word{Definition: "adj.新的"}, []string{posAdjective}},
+		{models.Word{Definition: "aux. 应该"}, nil},
+		{models.Word{Definition: "苹果"}, nil},
+		{models.Word{Definition: "e.g. 例如"}, nil},
+		{models.Word{Senses: []models.Sense{{PartOfSpeech: "n."}, {PartOfSpeech: "adj."}}}, []string{posNoun, posAdjective}},
+	}
+	for _, tt := range tests {
+		if got := wordClasses(tt.word); !slices.Equal(got, tt.want) {
+			t.Errorf("wordClasses(%+v) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
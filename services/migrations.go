@@ -0,0 +1,186 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// schemaMigration 记录已执行的数据库迁移
+type schemaMigration struct {
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string // 迁移说明
+	AppliedAt int64  // 执行时间戳
+}
+
+// TableName 指定迁移记录表名
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// migration 表示一次数据库结构升级
+type migration struct {
+	version int
+	name    string
+	up      func(tx *gorm.DB) error
+}
+
+// migrations 按版本顺序排列的所有迁移，只能在末尾追加
+// 没有迁移记录的旧数据库从第1版开始执行，因此每个迁移都需要能在旧结构上安全执行。
+// 迁移只使用SQL和迁移自己定义的结构体，不依赖 models 中的模型，
+// 以免模型的后续修改改变已发布版本的表结构
+var migrations = []migration{
+	{1, "create words, cards, settings and review logs", func(tx *gorm.DB) error {
+		// 旧版words表已存在时保留原表，其中的学习进度由第2版迁移移出
+		return execStatements(tx,
+			"CREATE TABLE IF NOT EXISTS `words` (`id` integer PRIMARY KEY AUTOINCREMENT,`word` text,`phonetic` text,"+
+				"`pronunciation` text,`definition` text,`example` text,`translation` text,`image_url` text,`difficulty` integer)",
+			"CREATE TABLE IF NOT EXISTS `cards` (`id` integer PRIMARY KEY AUTOINCREMENT,`word_id` integer,`card_type` text,"+
+				"`ord` integer,`state` text,`step` integer,`last_reviewed` integer,`next_review` integer,`review_count` integer,"+
+				"`ease_factor` real,`interval` integer,`stability` real,`memory_difficulty` real,`lapses` integer,"+
+				"`leech` numeric,`suspended` numeric,`buried_until` integer,"+
+				"CONSTRAINT `fk_words_cards` FOREIGN KEY (`word_id`) REFERENCES `words`(`id`))",
+			"CREATE UNIQUE INDEX IF NOT EXISTS `idx_cards_word_type` ON `cards`(`word_id`,`card_type`)",
+			"CREATE INDEX IF NOT EXISTS `idx_cards_state` ON `cards`(`state`)",
+			"CREATE TABLE IF NOT EXISTS `study_settings` (`id` integer PRIMARY KEY AUTOINCREMENT,`scheduler` text,"+
+				"`desired_retention` real,`maximum_interval` integer,`learning_steps` text DEFAULT '1m 10m',"+
+				"`relearning_steps` text DEFAULT '10m',`easy_interval` integer DEFAULT 4,`learn_ahead_limit` integer DEFAULT 20,"+
+				"`leech_threshold` integer DEFAULT 8,`leech_action` text DEFAULT 'suspend',`new_per_day` integer DEFAULT 20,"+
+				"`reviews_per_day` integer DEFAULT 200,`review_order` text DEFAULT 'overdue',`new_card_mix` text DEFAULT 'mix',"+
+				"`day_start_hour` integer DEFAULT 4,`fuzz` numeric DEFAULT true,`load_balance` numeric,"+
+				"`card_types` text DEFAULT 'recognition recall spelling cloze')",
+			"CREATE TABLE IF NOT EXISTS `review_logs` (`id` integer PRIMARY KEY AUTOINCREMENT,`word_id` integer,"+
+				"`card_id` integer,`reviewed_at` integer,`state` text,`quality` integer,`prev_interval` integer,"+
+				"`new_interval` integer,`prev_ease` real,`new_ease` real,`response_time` integer,`card_type` text)",
+			"CREATE INDEX IF NOT EXISTS `idx_review_logs_word_id` ON `review_logs`(`word_id`)",
+			"CREATE INDEX IF NOT EXISTS `idx_review_logs_card_id` ON `review_logs`(`card_id`)",
+			"CREATE INDEX IF NOT EXISTS `idx_review_logs_reviewed_at` ON `review_logs`(`reviewed_at`)",
+		)
+	}},
+	{2, "move legacy word progress into cards", migrateLegacyProgress},
+	{3, "create decks", func(tx *gorm.DB) error {
+		return execStatements(tx,
+			"CREATE TABLE IF NOT EXISTS `decks` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` text,`description` text,"+
+				"`scheduler` text,`created_at` integer)",
+			"CREATE UNIQUE INDEX IF NOT EXISTS `idx_decks_name` ON `decks`(`name`)",
+			"CREATE TABLE IF NOT EXISTS `deck_words` (`deck_id` integer,`word_id` integer,PRIMARY KEY (`deck_id`,`word_id`),"+
+				"CONSTRAINT `fk_deck_words_deck` FOREIGN KEY (`deck_id`) REFERENCES `decks`(`id`),"+
+				"CONSTRAINT `fk_deck_words_word` FOREIGN KEY (`word_id`) REFERENCES `words`(`id`))",
+		)
+	}},
+	{4, "create tags", func(tx *gorm.DB) error {
+		return execStatements(tx,
+			"CREATE TABLE IF NOT EXISTS `tags` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` text)",
+			"CREATE UNIQUE INDEX IF NOT EXISTS `idx_tags_name` ON `tags`(`name`)",
+			"CREATE TABLE IF NOT EXISTS `word_tags` (`tag_id` integer,`word_id` integer,PRIMARY KEY (`tag_id`,`word_id`),"+
+				"CONSTRAINT `fk_word_tags_tag` FOREIGN KEY (`tag_id`) REFERENCES `tags`(`id`),"+
+				"CONSTRAINT `fk_word_tags_word` FOREIGN KEY (`word_id`) REFERENCES `words`(`id`))",
+		)
+	}},
+	{5, "create word senses", migrateFlatSenses},
+	{6, "add word creation time", func(tx *gorm.DB) error {
+		if err := addColumn(tx, "words", "created_at", "integer"); err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE words SET created_at = 0 WHERE created_at IS NULL").Error
+	}},
+	{7, "add learner profiles", migrateProfiles},
+	{8, "create word relations", func(tx *gorm.DB) error {
+		return execStatements(tx,
+			"CREATE TABLE IF NOT EXISTS `word_relations` (`id` integer PRIMARY KEY AUTOINCREMENT,`word_id` integer,"+
+				"`related_id` integer,`type` text)",
+			"CREATE UNIQUE INDEX IF NOT EXISTS `idx_word_relations_pair` ON `word_relations`(`word_id`,`related_id`,`type`)",
+			"CREATE INDEX IF NOT EXISTS `idx_word_relations_word_id` ON `word_relations`(`word_id`)",
+			"CREATE INDEX IF NOT EXISTS `idx_word_relations_related_id` ON `word_relations`(`related_id`)",
+		)
+	}},
+	{9, "add normalized word keys and merge duplicates", migrateWordKeys},
+	{10, "add word trash", func(tx *gorm.DB) error {
+		if err := addColumn(tx, "words", "deleted_at", "datetime"); err != nil {
+			return err
+		}
+		return tx.Exec("CREATE INDEX IF NOT EXISTS `idx_words_deleted_at` ON `words`(`deleted_at`)").Error
+	}},
+	{11, "create word revisions", migrateWordRevisions},
+	{12, "enable only recognition cards by default", func(tx *gorm.DB) error {
+		// 之前默认启用全部卡片类型，但学习页面只能学习识别卡片；已创建的其他卡片保留，重新启用后可继续学习
+		return tx.Exec("UPDATE study_settings SET card_types = ? WHERE card_types = ?",
+			"recognition", "recognition recall spelling cloze").Error
+	}},
+}
+
+// execStatements 依次执行SQL语句
+func execStatements(tx *gorm.DB, statements ...string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumn 在表中添加列，列已存在时跳过
+func addColumn(tx *gorm.DB, table, column, columnType string) error {
+	if tx.Migrator().HasColumn(table, column) {
+		return nil
+	}
+	return tx.Exec("ALTER TABLE `" + table + "` ADD COLUMN `" + column + "` " + columnType).Error
+}
+
+// LatestSchemaVersion 返回当前程序支持的最新数据库版本
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// runMigrations 依次执行尚未执行的迁移，每个迁移在单独的事务中完成
+// 数据库版本高于程序支持的版本时拒绝打开；执行迁移前先备份已有数据库
+func runMigrations(db *gorm.DB, dataDir string) error {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return err
+	}
+
+	var current int
+	if err := db.Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&current).Error; err != nil {
+		return err
+	}
+
+	latest := LatestSchemaVersion()
+	if current > latest {
+		return fmt.Errorf("database schema version %d is newer than supported version %d, please upgrade WordMaster", current, latest)
+	}
+	if current == latest {
+		return nil
+	}
+
+	if db.Migrator().HasTable("words") {
+		if _, err := backupBeforeMigration(db, dataDir, current); err != nil {
+			return fmt.Errorf("failed to back up database before migration: %w", err)
+		}
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Version:   m.version,
+				Name:      m.name,
+				AppliedAt: time.Now().Unix(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.version, m.name, err)
+		}
+	}
+	return nil
+}
+
+// backupBeforeMigration 在迁移前将数据库完整复制到 backups 目录
+func backupBeforeMigration(db *gorm.DB, dataDir string, version int) (string, error) {
+	return backupDatabase(db, dataDir, fmt.Sprintf("v%d", version))
+}
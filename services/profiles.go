@@ -0,0 +1,153 @@
+package services
+
+import (
+	"WordMaster/models"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// profileCards 返回限定为当前学习者卡片的查询，不包括回收站中单词的卡片
+func (s *WordService) profileCards(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.Card{}).Where("cards.profile_id = ?", s.profileID).
+		Where("cards.word_id NOT IN " + trashedWordIDs)
+}
+
+// loadActiveProfile 读取上次使用的学习者，没有标记时使用最早创建的学习者
+func (s *WordService) loadActiveProfile() error {
+	var profile models.Profile
+	err := s.db.Where("active = ?", true).First(&profile).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = s.db.Order("id").First(&profile).Error
+	}
+	if err != nil {
+		return err
+	}
+	s.profileID = profile.ID
+	return nil
+}
+
+// GetProfiles 获取所有学习者
+func (s *WordService) GetProfiles() []models.Profile {
+	var profiles []models.Profile
+	s.db.Order("id").Find(&profiles)
+	return profiles
+}
+
+// GetActiveProfile 获取当前学习者
+func (s *WordService) GetActiveProfile() (models.Profile, error) {
+	var profile models.Profile
+	err := s.db.First(&profile, s.profileID).Error
+	return profile, err
+}
+
+// CreateProfile 创建学习者，新学习者使用默认学习设置，所有单词从未学习开始
+func (s *WordService) CreateProfile(name string) (models.Profile, error) {
+	profile := models.Profile{Name: strings.TrimSpace(name)}
+	if profile.Name == "" {
+		return models.Profile{}, errors.New("profile name cannot be empty")
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&profile).Error; err != nil {
+			return err
+		}
+		settings := models.DefaultStudySettings()
+		settings.ID = profile.ID
+		return tx.Create(&settings).Error
+	})
+	if err != nil {
+		return models.Profile{}, err
+	}
+	return profile, nil
+}
+
+// SwitchProfile 切换当前学习者，并记住以便下次启动时沿用
+func (s *WordService) SwitchProfile(id int) error {
+	var profile models.Profile
+	if err := s.db.First(&profile, id).Error; err != nil {
+		return err
+	}
+
+	// 先加载新学习者的设置并补齐其缺少的卡片，都成功后再切换，失败时保持当前学习者不变
+	next := &WordService{db: s.db, dataDir: s.dataDir, profileID: profile.ID, fts: s.fts}
+	if err := next.loadStudySettings(); err != nil {
+		return err
+	}
+	if err := next.ensureCards(); err != nil {
+		return err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Profile{}).Where("id <> ?", id).Update("active", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&profile).Update("active", true).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	s.profileID = next.profileID
+	s.settings = next.settings
+	s.scheduler = next.scheduler
+	return nil
+}
+
+// DeleteProfile 删除学习者及其学习进度、复习记录和设置，不能删除当前学习者
+func (s *WordService) DeleteProfile(id int) error {
+	if id == s.profileID {
+		return errors.New("cannot delete the active profile")
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Profile{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("profile %d not found", id)
+		}
+		if err := tx.Where("profile_id = ?", id).Delete(&models.Card{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("profile_id = ?", id).Delete(&models.ReviewLog{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.StudySettings{}, id).Error
+	})
+}
+
+// migrateProfiles 创建学习者表，已有的学习进度和设置归属默认学习者
+func migrateProfiles(tx *gorm.DB) error {
+	if err := execStatements(tx,
+		"CREATE TABLE IF NOT EXISTS `profiles` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` text,`active` numeric,"+
+			"`created_at` integer)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS `idx_profiles_name` ON `profiles`(`name`)",
+	); err != nil {
+		return err
+	}
+	if err := tx.Exec("INSERT OR IGNORE INTO profiles (id, name, active, created_at) VALUES (?, ?, ?, strftime('%s', 'now'))",
+		models.DefaultProfileID, "默认", true).Error; err != nil {
+		return err
+	}
+
+	for _, table := range []string{"cards", "review_logs"} {
+		if err := addColumn(tx, table, "profile_id", "integer"); err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE `"+table+"` SET profile_id = ? WHERE profile_id IS NULL OR profile_id = 0",
+			models.DefaultProfileID).Error; err != nil {
+			return err
+		}
+	}
+
+	// 卡片的唯一索引加入学习者
+	return execStatements(tx,
+		"DROP INDEX IF EXISTS idx_cards_word_type",
+		"CREATE UNIQUE INDEX IF NOT EXISTS `idx_cards_profile_word_type` ON `cards`(`profile_id`,`word_id`,`card_type`)",
+		"CREATE INDEX IF NOT EXISTS `idx_review_logs_profile_id` ON `review_logs`(`profile_id`)",
+	)
+}
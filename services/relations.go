@@ -0,0 +1,134 @@
+package services
+
+import (
+	"WordMaster/models"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// AddWordRelation 添加两个单词之间的关系，已存在时忽略
+func (s *WordService) AddWordRelation(wordID int, relatedID int, relationType string) error {
+	return addWordRelation(s.db, wordID, relatedID, relationType)
+}
+
+// RemoveWordRelation 删除两个单词之间的关系
+func (s *WordService) RemoveWordRelation(wordID int, relatedID int, relationType string) error {
+	relation, err := normalizeRelation(wordID, relatedID, relationType)
+	if err != nil {
+		return err
+	}
+	return s.db.Where("word_id = ? AND related_id = ? AND type = ?", relation.WordID, relation.RelatedID, relation.Type).
+		Delete(&models.WordRelation{}).Error
+}
+
+// GetRelatedWords 获取单词的所有关联单词，按关系类型和单词排序
+func (s *WordService) GetRelatedWords(wordID int) []models.RelatedWord {
+	related := s.relatedWords([]int{wordID})[wordID]
+	if related == nil {
+		return []models.RelatedWord{}
+	}
+	return related
+}
+
+// fillRelated 填充单词的关联单词
+func (s *WordService) fillRelated(words []models.Word) {
+	if len(words) == 0 {
+		return
+	}
+	ids := make([]int, len(words))
+	for i, word := range words {
+		ids[i] = word.ID
+	}
+	related := s.relatedWords(ids)
+	for i := range words {
+		words[i].Related = related[words[i].ID]
+	}
+}
+
+// relatedWords 查询多个单词的关联单词，反向的派生关系显示为派生词，不包括回收站中的单词
+func (s *WordService) relatedWords(wordIDs []int) map[int][]models.RelatedWord {
+	var rows []struct {
+		FromID int
+		models.RelatedWord
+	}
+	s.db.Raw(`SELECT r.word_id AS from_id, r.related_id AS id, r.type AS type, w.word AS word
+		FROM word_relations r JOIN words w ON w.id = r.related_id AND w.deleted_at IS NULL WHERE r.word_id IN @ids
+		UNION ALL
+		SELECT r.related_id, r.word_id, CASE WHEN r.type = @derivedFrom THEN @derivation ELSE r.type END, w.word
+		FROM word_relations r JOIN words w ON w.id = r.word_id AND w.deleted_at IS NULL WHERE r.related_id IN @ids
+		ORDER BY type, word`,
+		map[string]interface{}{
+			"ids":         wordIDs,
+			"derivedFrom": models.RelationDerivedFrom,
+			"derivation":  models.RelationDerivation,
+		}).Scan(&rows)
+
+	related := make(map[int][]models.RelatedWord)
+	for _, row := range rows {
+		related[row.FromID] = append(related[row.FromID], row.RelatedWord)
+	}
+	return related
+}
+
+// addWordRelation 添加单词关系，已存在时忽略
+func addWordRelation(tx *gorm.DB, wordID int, relatedID int, relationType string) error {
+	relation, err := normalizeRelation(wordID, relatedID, relationType)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	if err := tx.Model(&models.Word{}).Where("id IN ?", []int{wordID, relatedID}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != 2 {
+		return errors.New("word not found")
+	}
+	return insertRelation(tx, relation)
+}
+
+// insertRelation 保存已规范化的单词关系，已存在时忽略
+func insertRelation(tx *gorm.DB, relation models.WordRelation) error {
+	return tx.Exec("INSERT OR IGNORE INTO word_relations (word_id, related_id, type) VALUES (?, ?, ?)",
+		relation.WordID, relation.RelatedID, relation.Type).Error
+}
+
+// addImportedRelations 按单词文本添加导入文件中的关联单词，词库中不存在的关联单词跳过
+func addImportedRelations(tx *gorm.DB, wordID int, related []models.RelatedWord) error {
+	for _, r := range related {
+		var relatedID int
+		if err := tx.Model(&models.Word{}).Select("id").Where("word_key = ?", wordKey(r.Word)).Limit(1).
+			Scan(&relatedID).Error; err != nil {
+			return err
+		}
+		if relatedID == 0 || relatedID == wordID {
+			continue
+		}
+		if err := addWordRelation(tx, wordID, relatedID, r.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeRelation 将关系转换为保存的形式
+// 派生词关系保存为反向的派生自关系；对称关系只保存 WordID < RelatedID 的一条
+func normalizeRelation(wordID int, relatedID int, relationType string) (models.WordRelation, error) {
+	if !slices.Contains(models.RelationTypes, relationType) {
+		return models.WordRelation{}, fmt.Errorf("unknown relation type '%s'", relationType)
+	}
+	if wordID == relatedID {
+		return models.WordRelation{}, errors.New("a word cannot be related to itself")
+	}
+
+	switch relationType {
+	case models.RelationDerivation:
+		return models.WordRelation{WordID: relatedID, RelatedID: wordID, Type: models.RelationDerivedFrom}, nil
+	case models.RelationDerivedFrom:
+		return models.WordRelation{WordID: wordID, RelatedID: relatedID, Type: relationType}, nil
+	}
+	return models.WordRelation{WordID: min(wordID, relatedID), RelatedID: max(wordID, relatedID), Type: relationType}, nil
+}
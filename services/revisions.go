@@ -0,0 +1,215 @@
+package services
+
+import (
+	"WordMaster/models"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// revisionFields 记录修订历史的内容字段，顺序即显示顺序
+var revisionFields = []string{"word", "phonetic", "definition", "example", "translation", "imageUrl", "difficulty", "senses"}
+
+// senseSnapshot 修订记录中义项的JSON形式，字段名与 models.Sense 一致，不包含ID和顺序
+type senseSnapshot struct {
+	PartOfSpeech string            `json:"pos,omitempty"`
+	Definition   string            `json:"definition"`
+	Examples     []exampleSnapshot `json:"examples,omitempty"`
+}
+
+// exampleSnapshot 修订记录中例句的JSON形式
+type exampleSnapshot struct {
+	Text        string `json:"text"`
+	Translation string `json:"translation,omitempty"`
+}
+
+// wordContent 将单词的内容字段转换为文本，键为 revisionFields 中的字段名
+func wordContent(word models.Word) map[string]string {
+	senses := make([]senseSnapshot, len(word.Senses))
+	for i, sense := range word.Senses {
+		senses[i] = senseSnapshot{PartOfSpeech: sense.PartOfSpeech, Definition: sense.Definition}
+		for _, example := range sense.Examples {
+			senses[i].Examples = append(senses[i].Examples, exampleSnapshot{Text: example.Text, Translation: example.Translation})
+		}
+	}
+	sensesJSON := ""
+	if len(senses) > 0 {
+		data, _ := json.Marshal(senses)
+		sensesJSON = string(data)
+	}
+
+	return map[string]string{
+		"word":        word.Word,
+		"phonetic":    word.Phonetic,
+		"definition":  word.Definition,
+		"example":     word.Example,
+		"translation": word.Translation,
+		"imageUrl":    word.ImageURL,
+		"difficulty":  strconv.Itoa(word.Difficulty),
+		"senses":      sensesJSON,
+	}
+}
+
+// applyWordContent 用内容文本设置单词的内容字段
+func applyWordContent(word *models.Word, content map[string]string) error {
+	word.Word = content["word"]
+	word.Phonetic = content["phonetic"]
+	word.Definition = content["definition"]
+	word.Example = content["example"]
+	word.Translation = content["translation"]
+	word.ImageURL = content["imageUrl"]
+	word.Difficulty, _ = strconv.Atoi(content["difficulty"])
+	word.Senses = nil
+	if content["senses"] != "" {
+		if err := json.Unmarshal([]byte(content["senses"]), &word.Senses); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffContent 比较两份内容，返回有变化的字段
+func diffContent(old, new map[string]string) []models.FieldChange {
+	var changes []models.FieldChange
+	for _, field := range revisionFields {
+		if old[field] != new[field] {
+			changes = append(changes, models.FieldChange{Field: field, Old: old[field], New: new[field]})
+		}
+	}
+	return changes
+}
+
+// recordRevision 在事务中记录单词从 old 到 new 的修改，没有变化时不记录
+func recordRevision(tx *gorm.DB, wordID int, old, new map[string]string, source string, revertedTo int) error {
+	changes := diffContent(old, new)
+	if len(changes) == 0 {
+		return nil
+	}
+	return tx.Create(&models.WordRevision{
+		WordID:     wordID,
+		Source:     source,
+		RevertedTo: revertedTo,
+		Changes:    changes,
+	}).Error
+}
+
+// GetWordRevisions 获取单词的修订历史，最近的排在前面
+func (s *WordService) GetWordRevisions(wordID int) []models.WordRevision {
+	var revisions []models.WordRevision
+	s.db.Where("word_id = ?", wordID).Order("id DESC").Find(&revisions)
+	return revisions
+}
+
+// RevertWordRevision 将单词内容恢复到某次修订之后的状态，恢复操作本身也记录为一次修订
+func (s *WordService) RevertWordRevision(revisionID int) error {
+	var target models.WordRevision
+	if err := s.db.First(&target, revisionID).Error; err != nil {
+		return err
+	}
+	word, err := s.GetWordByID(target.WordID)
+	if err != nil {
+		return err
+	}
+
+	// 从当前内容开始，依次撤销之后的修订
+	var later []models.WordRevision
+	if err := s.db.Where("word_id = ? AND id > ?", target.WordID, target.ID).Order("id DESC").
+		Find(&later).Error; err != nil {
+		return err
+	}
+	content := wordContent(word)
+	for _, revision := range later {
+		for _, change := range revision.Changes {
+			content[change.Field] = change.Old
+		}
+	}
+	if err := applyWordContent(&word, content); err != nil {
+		return err
+	}
+	return s.updateWord(word, models.RevisionSourceRevert, target.ID)
+}
+
+// wordRevisionV11 第11版的修订历史表
+type wordRevisionV11 struct {
+	ID         int
+	WordID     int
+	Source     string
+	RevertedTo int
+	CreatedAt  int64                `gorm:"autoCreateTime"`
+	Changes    []models.FieldChange `gorm:"serializer:json"`
+}
+
+// TableName 指定修订历史表名
+func (wordRevisionV11) TableName() string {
+	return "word_revisions"
+}
+
+// migrateWordRevisions 创建修订历史表，并为已有单词记录当前内容作为第一条修订
+func migrateWordRevisions(tx *gorm.DB) error {
+	if err := execStatements(tx,
+		"CREATE TABLE IF NOT EXISTS `word_revisions` (`id` integer PRIMARY KEY AUTOINCREMENT,`word_id` integer,"+
+			"`source` text,`reverted_to` integer,`created_at` integer,`changes` text)",
+		"CREATE INDEX IF NOT EXISTS `idx_word_revisions_word_id` ON `word_revisions`(`word_id`)",
+	); err != nil {
+		return err
+	}
+
+	var words []struct {
+		ID          int
+		Word        string
+		Phonetic    string
+		Definition  string
+		Example     string
+		Translation string
+		ImageURL    string
+		Difficulty  int
+	}
+	if err := tx.Table("words").
+		Select("id", "word", "phonetic", "definition", "example", "translation", "image_url", "difficulty").
+		Where("id NOT IN (SELECT word_id FROM word_revisions)").Find(&words).Error; err != nil {
+		return err
+	}
+	var senses []senseV5
+	if err := tx.Where("word_id NOT IN (SELECT word_id FROM word_revisions)").Order("ord").Find(&senses).Error; err != nil {
+		return err
+	}
+	var examples []senseExampleV5
+	if err := tx.Where("sense_id IN (SELECT id FROM senses WHERE word_id NOT IN (SELECT word_id FROM word_revisions))").
+		Order("ord").Find(&examples).Error; err != nil {
+		return err
+	}
+	senseExamples := make(map[int][]models.SenseExample)
+	for _, example := range examples {
+		senseExamples[example.SenseID] = append(senseExamples[example.SenseID],
+			models.SenseExample{Text: example.Text, Translation: example.Translation})
+	}
+	wordSenses := make(map[int][]models.Sense)
+	for _, sense := range senses {
+		wordSenses[sense.WordID] = append(wordSenses[sense.WordID], models.Sense{
+			PartOfSpeech: sense.PartOfSpeech,
+			Definition:   sense.Definition,
+			Examples:     senseExamples[sense.ID],
+		})
+	}
+
+	for _, word := range words {
+		content := wordContent(models.Word{
+			Word:        word.Word,
+			Phonetic:    word.Phonetic,
+			Definition:  word.Definition,
+			Example:     word.Example,
+			Translation: word.Translation,
+			ImageURL:    word.ImageURL,
+			Difficulty:  word.Difficulty,
+			Senses:      wordSenses[word.ID],
+		})
+		changes := diffContent(map[string]string{}, content)
+		revision := wordRevisionV11{WordID: word.ID, Source: models.RevisionSourceInitial, Changes: changes}
+		if err := tx.Create(&revision).Error; err != nil {
+			return fmt.Errorf("failed to record revision for word %d: %w", word.ID, err)
+		}
+	}
+	return nil
+}
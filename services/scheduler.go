@@ -0,0 +1,51 @@
+package services
+
+import (
+	"WordMaster/models"
+	"fmt"
+	"time"
+)
+
+// 可选的调度算法名称
+const (
+	SchedulerSM2  = "sm2"
+	SchedulerFSRS = "fsrs"
+)
+
+// Scheduler 间隔重复调度器
+// 调度器只负责根据回答质量更新记忆参数和复习间隔，
+// 复习时间戳由WordService统一写入
+type Scheduler interface {
+	// Name 返回调度算法名称
+	Name() string
+	// Schedule 根据回答质量(0-5)更新调度状态，now为本次复习时间
+	Schedule(state *models.ReviewState, quality int, now time.Time)
+}
+
+// NewScheduler 根据学习设置创建调度器
+func NewScheduler(settings models.StudySettings) (Scheduler, error) {
+	switch settings.Scheduler {
+	case SchedulerSM2, "":
+		return &SM2Scheduler{MaximumInterval: settings.MaximumInterval}, nil
+	case SchedulerFSRS:
+		return NewFSRSScheduler(settings.DesiredRetention, settings.MaximumInterval), nil
+	default:
+		return nil, fmt.Errorf("unknown scheduler '%s'", settings.Scheduler)
+	}
+}
+
+// AvailableSchedulers 返回所有可选的调度算法名称
+func AvailableSchedulers() []string {
+	return []string{SchedulerSM2, SchedulerFSRS}
+}
+
+// capInterval 将间隔限制在 [1, maximum] 天之间
+func capInterval(interval int, maximum int) int {
+	if interval < 1 {
+		interval = 1
+	}
+	if maximum > 0 && interval > maximum {
+		interval = maximum
+	}
+	return interval
+}
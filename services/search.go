@@ -0,0 +1,335 @@
+package services
+
+import (
+	"WordMaster/models"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// 高亮标记，转义HTML后再替换为<mark>标签
+const (
+	markStart = "\x02"
+	markEnd   = "\x03"
+)
+
+// searchFields 参与全文搜索的单词字段，顺序与全文索引的列一致
+var searchFields = []string{"word", "definition", "example", "translation"}
+
+// searchTriggers 保持全文索引与words表同步的触发器
+var searchTriggers = []struct {
+	name string
+	sql  string
+}{
+	{"words_fts_ai", `CREATE TRIGGER words_fts_ai AFTER INSERT ON words BEGIN
+		INSERT INTO words_fts (rowid, word, definition, example, translation)
+		VALUES (new.id, new.word, new.definition, new.example, new.translation);
+	END`},
+	{"words_fts_ad", `CREATE TRIGGER words_fts_ad AFTER DELETE ON words BEGIN
+		INSERT INTO words_fts (words_fts, rowid, word, definition, example, translation)
+		VALUES ('delete', old.id, old.word, old.definition, old.example, old.translation);
+	END`},
+	{"words_fts_au", `CREATE TRIGGER words_fts_au AFTER UPDATE ON words BEGIN
+		INSERT INTO words_fts (words_fts, rowid, word, definition, example, translation)
+		VALUES ('delete', old.id, old.word, old.definition, old.example, old.translation);
+		INSERT INTO words_fts (rowid, word, definition, example, translation)
+		VALUES (new.id, new.word, new.definition, new.example, new.translation);
+	END`},
+}
+
+// searchRow 表示一行搜索结果，文本字段可能已插入高亮标记
+type searchRow struct {
+	ID          int
+	Rank        float64
+	Word        string
+	Definition  string
+	Example     string
+	Translation string
+}
+
+// fields 按 searchFields 的顺序返回文本字段
+func (row searchRow) fields() []string {
+	return []string{row.Word, row.Definition, row.Example, row.Translation}
+}
+
+// result 生成尚未加载单词内容的搜索结果
+func (row searchRow) result() models.SearchResult {
+	return models.SearchResult{
+		Word:       models.Word{ID: row.ID},
+		Rank:       row.Rank,
+		Highlights: make(map[string]string),
+	}
+}
+
+// minFTSTermLength trigram分词器能匹配的最短搜索词长度
+const minFTSTermLength = 3
+
+// setupSearchIndex 创建全文索引及同步触发器
+// FTS5 需要使用 sqlite_fts5 构建标签编译；不可用时删除触发器(否则写入words表会失败)，搜索回退到LIKE查询。
+// 触发器缺失时(新数据库或曾被不支持FTS5的版本打开)重建索引
+func (s *WordService) setupSearchIndex() error {
+	var enabled int
+	s.db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled)
+	s.fts = enabled == 1
+
+	names := make([]string, len(searchTriggers))
+	for i, trigger := range searchTriggers {
+		names[i] = trigger.name
+	}
+
+	if !s.fts {
+		for _, name := range names {
+			if err := s.db.Exec("DROP TRIGGER IF EXISTS " + name).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var existing int64
+	if err := s.db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name IN ?", names).
+		Scan(&existing).Error; err != nil {
+		return err
+	}
+	if int(existing) == len(searchTriggers) {
+		return nil
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 使用trigram分词，中文释义和英文单词都可以按子串匹配
+		if err := tx.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS words_fts USING fts5(
+			word, definition, example, translation,
+			content='words', content_rowid='id', tokenize='trigram')`).Error; err != nil {
+			return err
+		}
+		for _, trigger := range searchTriggers {
+			if err := tx.Exec("DROP TRIGGER IF EXISTS " + trigger.name).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(trigger.sql).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Exec("INSERT INTO words_fts (words_fts) VALUES ('rebuild')").Error
+	})
+}
+
+// SearchWords 在单词、释义、例句和例句翻译中搜索，按相关度排序并返回高亮片段
+// 多个搜索词之间为"且"关系；搜索词为变形时同时搜索词库中的原形，如 ran 也能找到 run。
+// FTS5不可用或搜索词少于3个字符时使用LIKE查询
+func (s *WordService) SearchWords(query string, limit int, offset int) ([]models.SearchResult, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []models.SearchResult{}, nil
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	offset = max(offset, 0)
+
+	groups, err := s.searchTermForms(terms)
+	if err != nil {
+		return nil, err
+	}
+	useFTS := s.fts
+	for _, forms := range groups {
+		for _, form := range forms {
+			if utf8.RuneCountInString(form) < minFTSTermLength {
+				useFTS = false
+			}
+		}
+	}
+
+	var results []models.SearchResult
+	if useFTS {
+		results, err = s.searchFTS(groups, limit, offset)
+	} else {
+		results, err = s.searchLike(groups, limit, offset)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 加载完整的单词内容
+	ids := make([]int, len(results))
+	for i, result := range results {
+		ids[i] = result.Word.ID
+	}
+	var words []models.Word
+	if err := preloadSenses(s.db).Preload("Tags").Where("id IN ?", ids).Find(&words).Error; err != nil {
+		return nil, err
+	}
+	s.fillProgress(words)
+	byID := make(map[int]models.Word, len(words))
+	for _, word := range words {
+		byID[word.ID] = word
+	}
+	for i := range results {
+		results[i].Word = byID[results[i].Word.ID]
+	}
+	return results, nil
+}
+
+// searchFTS 使用全文索引搜索，与第一个搜索词或其原形完全相同的单词排在最前，其余按bm25排序(单词列权重最高)
+// groups 为每个搜索词及其原形，同一组内为"或"关系
+func (s *WordService) searchFTS(groups [][]string, limit int, offset int) ([]models.SearchResult, error) {
+	conditions := make([]string, len(groups))
+	for i, forms := range groups {
+		phrases := make([]string, len(forms))
+		for j, form := range forms {
+			phrases[j] = `"` + strings.ReplaceAll(form, `"`, `""`) + `"`
+		}
+		conditions[i] = "(" + strings.Join(phrases, " OR ") + ")"
+	}
+
+	var rows []searchRow
+	err := s.db.Raw(`SELECT words_fts.rowid AS id,
+			bm25(words_fts, 10.0, 5.0, 1.0, 1.0) AS rank,
+			highlight(words_fts, 0, @start, @end) AS word,
+			snippet(words_fts, 1, @start, @end, '…', 16) AS definition,
+			snippet(words_fts, 2, @start, @end, '…', 16) AS example,
+			snippet(words_fts, 3, @start, @end, '…', 16) AS translation
+		FROM words_fts JOIN words ON words.id = words_fts.rowid
+		WHERE words_fts MATCH @query AND words.deleted_at IS NULL
+		ORDER BY words.word = @first COLLATE NOCASE OR words.word IN @lemmas DESC, rank LIMIT @limit OFFSET @offset`,
+		map[string]interface{}{
+			"start":  markStart,
+			"end":    markEnd,
+			"query":  strings.Join(conditions, " "),
+			"first":  groups[0][0],
+			"lemmas": groups[0],
+			"limit":  limit,
+			"offset": offset,
+		}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.SearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = row.result()
+		for j, text := range row.fields() {
+			if strings.Contains(text, markStart) {
+				results[i].Highlights[searchFields[j]] = markToHTML(text)
+			}
+		}
+	}
+	return results, nil
+}
+
+// searchLike 使用LIKE查询搜索，groups 为每个搜索词及其原形
+// 排序：单词与第一个搜索词或其原形相同、以其开头、包含它，其次是只在释义或例句中命中的单词
+func (s *WordService) searchLike(groups [][]string, limit int, offset int) ([]models.SearchResult, error) {
+	query := s.db.Model(&models.Word{})
+	var terms []string
+	for _, forms := range groups {
+		var conditions []string
+		var args []interface{}
+		for _, form := range forms {
+			pattern := "%" + escapeLike(form) + "%"
+			conditions = append(conditions, `word LIKE ? ESCAPE '\' OR definition LIKE ? ESCAPE '\' OR example LIKE ? ESCAPE '\' OR translation LIKE ? ESCAPE '\'`)
+			args = append(args, pattern, pattern, pattern, pattern)
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+		terms = append(terms, forms...)
+	}
+
+	first := escapeLike(groups[0][0])
+	var rows []searchRow
+	err := query.Select(`id, word, definition, example, translation,
+			CASE WHEN word LIKE ? ESCAPE '\' OR word IN ? THEN 0
+				WHEN word LIKE ? ESCAPE '\' THEN 1
+				WHEN word LIKE ? ESCAPE '\' THEN 2
+				ELSE 3 END AS rank`,
+		first, groups[0], first+"%", "%"+first+"%").
+		Order("rank, length(word), id").Limit(limit).Offset(offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.SearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = row.result()
+		for j, text := range row.fields() {
+			if marked, ok := highlightTerms(text, terms, j > 0); ok {
+				results[i].Highlights[searchFields[j]] = markToHTML(marked)
+			}
+		}
+	}
+	return results, nil
+}
+
+// searchTerms 将搜索语句拆分为搜索词
+func searchTerms(query string) []string {
+	return strings.Fields(strings.ReplaceAll(query, `"`, " "))
+}
+
+// snippetRadius LIKE搜索生成片段时保留的命中位置前后字符数
+const snippetRadius = 20
+
+// highlightTerms 用高亮标记包围text中出现的搜索词(不区分大小写)，没有命中时返回false
+// snippet 为true时只保留第一个命中位置附近的文本
+func highlightTerms(text string, terms []string, snippet bool) (string, bool) {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	found := false
+	for _, term := range terms {
+		needle := []rune(strings.ToLower(term))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if string(lower[i:i+len(needle)]) == string(needle) {
+				for j := i; j < i+len(needle); j++ {
+					marked[j] = true
+				}
+				found = true
+			}
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	from, to := 0, len(runes)
+	if snippet {
+		firstMatch := 0
+		for !marked[firstMatch] {
+			firstMatch++
+		}
+		from = max(firstMatch-snippetRadius, 0)
+		to = min(firstMatch+snippetRadius*2, len(runes))
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	for i := from; i < to; i++ {
+		if marked[i] && (i == from || !marked[i-1]) {
+			b.WriteString(markStart)
+		}
+		b.WriteRune(runes[i])
+		if marked[i] && (i == to-1 || !marked[i+1]) {
+			b.WriteString(markEnd)
+		}
+	}
+	if to < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// markToHTML 转义文本中的HTML并将高亮标记替换为<mark>标签
+func markToHTML(text string) string {
+	return strings.NewReplacer(markStart, "<mark>", markEnd, "</mark>").Replace(html.EscapeString(text))
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"WordMaster/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// normalizeSenses 整理单词的义项并据此汇总释义和例句字段
+// 没有有效义项时(如旧格式的导入数据)，由 Definition/Example/Translation 生成一个义项
+func normalizeSenses(word *models.Word) {
+	var senses []models.Sense
+	for _, sense := range word.Senses {
+		sense.PartOfSpeech = strings.TrimSpace(sense.PartOfSpeech)
+		sense.Definition = strings.TrimSpace(sense.Definition)
+
+		var examples []models.SenseExample
+		for _, example := range sense.Examples {
+			example.Text = strings.TrimSpace(example.Text)
+			example.Translation = strings.TrimSpace(example.Translation)
+			if example.Text == "" && example.Translation == "" {
+				continue
+			}
+			examples = append(examples, example)
+		}
+		sense.Examples = examples
+
+		if sense.Definition == "" && len(sense.Examples) == 0 {
+			continue
+		}
+		senses = append(senses, sense)
+	}
+
+	if len(senses) == 0 {
+		word.Senses = nil
+		if word.Definition == "" && word.Example == "" && word.Translation == "" {
+			return
+		}
+		sense := models.Sense{Definition: word.Definition}
+		if word.Example != "" || word.Translation != "" {
+			sense.Examples = []models.SenseExample{{Text: word.Example, Translation: word.Translation}}
+		}
+		senses = []models.Sense{sense}
+	}
+	word.Senses = senses
+
+	// 汇总：释义为各义项的"词性 释义"，例句取第一条
+	definitions := make([]string, 0, len(senses))
+	word.Example, word.Translation = "", ""
+	exampleFound := false
+	for _, sense := range senses {
+		if sense.Definition != "" {
+			definitions = append(definitions, strings.TrimSpace(sense.PartOfSpeech+" "+sense.Definition))
+		}
+		if !exampleFound && len(sense.Examples) > 0 {
+			word.Example = sense.Examples[0].Text
+			word.Translation = sense.Examples[0].Translation
+			exampleFound = true
+		}
+	}
+	word.Definition = strings.Join(definitions, "; ")
+}
+
+// saveSenses 在事务中用word.Senses替换单词原有的义项和例句
+func saveSenses(tx *gorm.DB, word models.Word) error {
+	if err := deleteSenses(tx, word.ID); err != nil {
+		return err
+	}
+	for i, sense := range word.Senses {
+		examples := sense.Examples
+		sense.ID = 0
+		sense.WordID = word.ID
+		sense.Ord = i
+		sense.Examples = nil
+		if err := tx.Create(&sense).Error; err != nil {
+			return err
+		}
+		for j, example := range examples {
+			example.ID = 0
+			example.SenseID = sense.ID
+			example.Ord = j
+			if err := tx.Create(&example).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteSenses 在事务中删除单词的所有义项和例句
+func deleteSenses(tx *gorm.DB, wordID int) error {
+	if err := tx.Where("sense_id IN (SELECT id FROM senses WHERE word_id = ?)", wordID).
+		Delete(&models.SenseExample{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("word_id = ?", wordID).Delete(&models.Sense{}).Error
+}
+
+// preloadSenses 按顺序预加载单词的义项及例句
+func preloadSenses(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Senses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("ord")
+		}).
+		Preload("Senses.Examples", func(db *gorm.DB) *gorm.DB {
+			return db.Order("ord")
+		})
+}
+
+// senseV5 第5版的义项表
+type senseV5 struct {
+	ID           int
+	WordID       int
+	Ord          int
+	PartOfSpeech string
+	Definition   string
+}
+
+// TableName 指定义项表名
+func (senseV5) TableName() string {
+	return "senses"
+}
+
+// senseExampleV5 第5版的义项例句表
+type senseExampleV5 struct {
+	ID          int
+	SenseID     int
+	Ord         int
+	Text        string
+	Translation string
+}
+
+// TableName 指定义项例句表名
+func (senseExampleV5) TableName() string {
+	return "sense_examples"
+}
+
+// migrateFlatSenses 创建义项表，并为已有单词根据释义和例句字段生成一个义项
+func migrateFlatSenses(tx *gorm.DB) error {
+	if err := execStatements(tx,
+		"CREATE TABLE IF NOT EXISTS `senses` (`id` integer PRIMARY KEY AUTOINCREMENT,`word_id` integer,`ord` integer,"+
+			"`part_of_speech` text,`definition` text,"+
+			"CONSTRAINT `fk_words_senses` FOREIGN KEY (`word_id`) REFERENCES `words`(`id`))",
+		"CREATE INDEX IF NOT EXISTS `idx_senses_word_id` ON `senses`(`word_id`)",
+		"CREATE TABLE IF NOT EXISTS `sense_examples` (`id` integer PRIMARY KEY AUTOINCREMENT,`sense_id` integer,"+
+			"`ord` integer,`text` text,`translation` text,"+
+			"CONSTRAINT `fk_senses_examples` FOREIGN KEY (`sense_id`) REFERENCES `senses`(`id`))",
+		"CREATE INDEX IF NOT EXISTS `idx_sense_examples_sense_id` ON `sense_examples`(`sense_id`)",
+	); err != nil {
+		return err
+	}
+
+	var words []struct {
+		ID          int
+		Definition  string
+		Example     string
+		Translation string
+	}
+	if err := tx.Table("words").Select("id", "definition", "example", "translation").
+		Where("id NOT IN (SELECT word_id FROM senses)").Find(&words).Error; err != nil {
+		return err
+	}
+	for _, word := range words {
+		if word.Definition == "" && word.Example == "" && word.Translation == "" {
+			continue
+		}
+		sense := senseV5{WordID: word.ID, Definition: word.Definition}
+		if err := tx.Create(&sense).Error; err != nil {
+			return err
+		}
+		if word.Example == "" && word.Translation == "" {
+			continue
+		}
+		example := senseExampleV5{SenseID: sense.ID, Text: word.Example, Translation: word.Translation}
+		if err := tx.Create(&example).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
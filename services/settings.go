@@ -0,0 +1,100 @@
+package services
+
+import (
+	"WordMaster/models"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// loadStudySettings 读取当前学习者的学习设置，不存在时写入默认值
+func (s *WordService) loadStudySettings() error {
+	var settings models.StudySettings
+	err := s.db.First(&settings, s.profileID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		settings = models.DefaultStudySettings()
+		settings.ID = s.profileID
+		if err := s.db.Create(&settings).Error; err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	return s.applyStudySettings(settings)
+}
+
+// applyStudySettings 应用学习设置并切换到对应的调度器
+func (s *WordService) applyStudySettings(settings models.StudySettings) error {
+	scheduler, err := NewScheduler(settings)
+	if err != nil {
+		return err
+	}
+	s.settings = settings
+	s.scheduler = scheduler
+	return nil
+}
+
+// GetStudySettings 获取当前学习者的学习设置
+func (s *WordService) GetStudySettings() models.StudySettings {
+	return s.settings
+}
+
+// UpdateStudySettings 更新当前学习者的学习设置
+func (s *WordService) UpdateStudySettings(settings models.StudySettings) error {
+	settings.ID = s.profileID
+	if settings.DesiredRetention <= 0 || settings.DesiredRetention >= 1 {
+		return errors.New("desired retention must be between 0 and 1")
+	}
+	if settings.LeechAction != models.LeechActionSuspend && settings.LeechAction != models.LeechActionTag {
+		return fmt.Errorf("unknown leech action '%s'", settings.LeechAction)
+	}
+	if settings.NewPerDay < 0 || settings.ReviewsPerDay < 0 {
+		return errors.New("daily limits must not be negative")
+	}
+	if settings.MaximumInterval < 0 || settings.EasyInterval < 0 {
+		return errors.New("intervals must not be negative")
+	}
+	if settings.LearnAheadLimit < 0 {
+		return errors.New("learn ahead limit must not be negative")
+	}
+	if settings.LeechThreshold < 0 {
+		return errors.New("leech threshold must not be negative")
+	}
+	switch settings.ReviewOrder {
+	case models.ReviewOrderDue, models.ReviewOrderOverdue, models.ReviewOrderRetrievability:
+	default:
+		return fmt.Errorf("unknown review order '%s'", settings.ReviewOrder)
+	}
+	switch settings.NewCardMix {
+	case models.NewCardMixInterleave, models.NewCardMixBefore, models.NewCardMixAfter:
+	default:
+		return fmt.Errorf("unknown new card mix '%s'", settings.NewCardMix)
+	}
+	if settings.DayStartHour < 0 || settings.DayStartHour > 23 {
+		return errors.New("day start hour must be between 0 and 23")
+	}
+	if _, err := NewScheduler(settings); err != nil {
+		return err
+	}
+	if _, err := ParseSteps(settings.LearningSteps); err != nil {
+		return err
+	}
+	if _, err := ParseSteps(settings.RelearningSteps); err != nil {
+		return err
+	}
+	if _, err := ParseCardTypes(settings.CardTypes); err != nil {
+		return err
+	}
+
+	if err := s.db.Save(&settings).Error; err != nil {
+		return err
+	}
+	if err := s.applyStudySettings(settings); err != nil {
+		return err
+	}
+
+	// 新启用的卡片类型需要生成卡片
+	return s.ensureCards()
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"WordMaster/models"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// maxSimulationDays 模拟的最大天数
+const maxSimulationDays = 3650
+
+// GetReviewForecast 根据当前的复习计划预测未来days天每天的复习量
+func (s *WordService) GetReviewForecast(days int) ([]models.ForecastDay, error) {
+	result, err := s.Simulate(models.SimulationParams{Days: days})
+	if err != nil {
+		return nil, err
+	}
+	return result.Days, nil
+}
+
+// Simulate 模拟未来的学习与复习量
+// 以卡片为单位，每次复习按当时的回忆概率随机判定记住或遗忘，使用固定随机种子以保证结果可重复
+// SM-2 不支持目标记忆保持率，指定 DesiredRetention 且当前算法为 SM-2 时改用 FSRS 模拟；
+// 同时显式指定 SM-2 时返回错误
+func (s *WordService) Simulate(params models.SimulationParams) (models.SimulationResult, error) {
+	if params.Days <= 0 || params.Days > maxSimulationDays {
+		return models.SimulationResult{}, errors.New("days must be between 1 and 3650")
+	}
+	if params.NewPerDay < 0 || params.AdditionalWords < 0 {
+		return models.SimulationResult{}, errors.New("word counts must not be negative")
+	}
+
+	settings := s.settings
+	if params.Scheduler != "" {
+		settings.Scheduler = params.Scheduler
+	}
+	if params.DesiredRetention != 0 {
+		if params.DesiredRetention <= 0 || params.DesiredRetention >= 1 {
+			return models.SimulationResult{}, errors.New("desired retention must be between 0 and 1")
+		}
+		if params.Scheduler == SchedulerSM2 {
+			return models.SimulationResult{}, errors.New("desired retention is not supported by the sm2 scheduler")
+		}
+		settings.DesiredRetention = params.DesiredRetention
+		if settings.Scheduler == SchedulerSM2 || settings.Scheduler == "" {
+			settings.Scheduler = SchedulerFSRS
+		}
+	}
+	scheduler, err := NewScheduler(settings)
+	if err != nil {
+		return models.SimulationResult{}, err
+	}
+	newPerDay := params.NewPerDay
+	if newPerDay == 0 {
+		newPerDay = settings.NewPerDay
+	}
+
+	now := time.Now()
+	today := s.dayStart(now)
+
+	// 按到期日分组现有的复习卡片，已逾期的计入第0天
+	cardTypes := s.enabledCardTypes()
+	var cards []models.Card
+	s.profileCards(s.db).Where("suspended = ? AND card_type IN ? AND state <> ?", false, cardTypes, models.StateNew).Find(&cards)
+	due := make([][]models.ReviewState, params.Days)
+	for _, card := range cards {
+		day := max(int(time.Unix(card.NextReview, 0).Sub(today).Hours()/24), 0)
+		if day < params.Days {
+			due[day] = append(due[day], card.ReviewState)
+		}
+	}
+
+	// 计划导入的单词按启用的卡片类型数折算为新卡片
+	var newCount int64
+	s.profileCards(s.db).
+		Where("suspended = ? AND card_type IN ? AND state = ?", false, cardTypes, models.StateNew).
+		Count(&newCount)
+	remainingNew := int(newCount) + params.AdditionalWords*len(cardTypes)
+
+	rng := rand.New(rand.NewSource(1))
+	result := models.SimulationResult{Days: make([]models.ForecastDay, params.Days)}
+	for day := 0; day < params.Days; day++ {
+		simNow := today.AddDate(0, 0, day).Add(now.Sub(today))
+		forecast := &result.Days[day]
+		forecast.Date = simNow.Format("2006-01-02")
+
+		for _, state := range due[day] {
+			quality := 4
+			if rng.Float64() > retrievabilityAt(state, simNow) {
+				quality = 1
+				forecast.Lapses++
+			}
+			forecast.Reviews++
+			scheduler.Schedule(&state, quality, simNow)
+			state.LastReviewed = simNow.Unix()
+			if next := day + max(state.Interval, 1); next < params.Days {
+				due[next] = append(due[next], state)
+			}
+		}
+
+		learn := min(newPerDay, remainingNew)
+		for i := 0; i < learn; i++ {
+			state := models.ReviewState{State: models.StateNew, EaseFactor: 2.5}
+			scheduler.Schedule(&state, 4, simNow)
+			state.LastReviewed = simNow.Unix()
+			if next := day + max(state.Interval, 1); next < params.Days {
+				due[next] = append(due[next], state)
+			}
+		}
+		forecast.NewWords = learn
+		remainingNew -= learn
+
+		result.TotalReviews += forecast.Reviews
+		result.LearnedWords += learn
+		result.PeakReviews = max(result.PeakReviews, forecast.Reviews)
+	}
+
+	result.AverageReviews = float64(result.TotalReviews) / float64(params.Days)
+	result.RemainingNew = remainingNew
+	return result, nil
+}
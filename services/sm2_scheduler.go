@@ -0,0 +1,44 @@
+package services
+
+import (
+	"WordMaster/models"
+	"math"
+	"time"
+)
+
+// SM2Scheduler 基于SM-2算法的调度器
+type SM2Scheduler struct {
+	MaximumInterval int // 最大复习间隔(天)，0表示不限制
+}
+
+// Name 返回调度算法名称
+func (s *SM2Scheduler) Name() string {
+	return SchedulerSM2
+}
+
+// Schedule 根据回答质量更新间隔和简易度因子
+func (s *SM2Scheduler) Schedule(state *models.ReviewState, quality int, now time.Time) {
+	state.ReviewCount++
+
+	// 计算新的间隔
+	if quality >= 3 {
+		if state.ReviewCount == 1 {
+			state.Interval = 1
+		} else if state.ReviewCount == 2 {
+			state.Interval = 6
+		} else {
+			state.Interval = int(math.Round(float64(state.Interval) * state.EaseFactor))
+		}
+		state.EaseFactor = state.EaseFactor + (0.1 - float64(5-quality)*(0.08+float64(5-quality)*0.02))
+	} else {
+		state.Interval = 1
+		state.ReviewCount = 0
+	}
+
+	// 限制易度因子范围
+	if state.EaseFactor < 1.3 {
+		state.EaseFactor = 1.3
+	}
+
+	state.Interval = capInterval(state.Interval, s.MaximumInterval)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"WordMaster/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetStudyQueue 构建今日学习队列
+// 已到期的学习中卡片优先，其后按每日上限截取复习和新卡片，并按设置的方式混合；
+// 没有任何到期卡片时，提前取回在 LearnAheadLimit 窗口内到期的学习中卡片。
+// 同一单词每天只出现一张卡片。deckID 不为0时只包含该单词本中的单词
+func (s *WordService) GetStudyQueue(deckID int) models.StudyQueue {
+	now := time.Now()
+	queue := s.dailyBudget(now)
+	cardTypes := s.enabledCardTypes()
+
+	// 学习中/重新学习中的卡片不受每日上限限制
+	cards := func() *gorm.DB {
+		return inDeck(s.profileCards(s.db).Preload("Word"), deckID, "word_id")
+	}
+
+	learningDue := func(cutoff int64) []models.Card {
+		var learning []models.Card
+		cards().
+			Where("suspended = ? AND card_type IN ? AND state IN ? AND next_review <= ?", false, cardTypes,
+				[]string{models.StateLearning, models.StateRelearning}, cutoff).
+			Order("next_review").Find(&learning)
+		return learning
+	}
+	learning := learningDue(now.Unix())
+	seen := make(map[int]bool)
+	for _, card := range learning {
+		seen[card.WordID] = true
+	}
+
+	var reviews []models.Card
+	cards().
+		Where("suspended = ? AND card_type IN ? AND state = ? AND next_review <= ? AND buried_until <= ?",
+			false, cardTypes, models.StateReview, now.Unix(), now.Unix()).
+		Find(&reviews)
+	s.sortReviews(reviews, now)
+	reviews = takeOnePerWord(reviews, seen, queue.ReviewLimit)
+
+	var newCards []models.Card
+	if queue.NewLimit > 0 {
+		// 每个单词最多取一张，因此按卡片类型数放大查询数量
+		cards().
+			Where("suspended = ? AND card_type IN ? AND state = ? AND buried_until <= ?",
+				false, cardTypes, models.StateNew, now.Unix()).
+			Order("word_id, ord").Limit(queue.NewLimit * max(len(cardTypes), 1)).Find(&newCards)
+		newCards = takeOnePerWord(newCards, seen, queue.NewLimit)
+	}
+
+	// 没有其他到期卡片时，提前取回学习窗口内到期的学习中卡片
+	if len(learning)+len(reviews)+len(newCards) == 0 {
+		learning = learningDue(s.learnAheadCutoff(now))
+	}
+
+	queue.LearningCount = len(learning)
+	queue.ReviewCount = len(reviews)
+	queue.NewCount = len(newCards)
+	queue.Cards = append(learning, mixNewCards(reviews, newCards, s.settings.NewCardMix)...)
+	fillCloze(queue.Cards)
+	return queue
+}
+
+// dailyBudget 返回今日已完成的新卡片和复习数量，以及剩余的每日上限
+func (s *WordService) dailyBudget(now time.Time) models.StudyQueue {
+	dayStart := s.dayStart(now)
+	queue := models.StudyQueue{
+		NewDoneToday:     s.countReviewsSince(dayStart, models.StateNew),
+		ReviewsDoneToday: s.countReviewsSince(dayStart, models.StateReview),
+	}
+	queue.NewLimit = max(0, s.settings.NewPerDay-queue.NewDoneToday)
+	queue.ReviewLimit = max(0, s.settings.ReviewsPerDay-queue.ReviewsDoneToday)
+	return queue
+}
+
+// takeOnePerWord 按顺序选取最多limit张卡片，跳过seen中已出现单词的卡片
+func takeOnePerWord(cards []models.Card, seen map[int]bool, limit int) []models.Card {
+	result := make([]models.Card, 0, min(len(cards), limit))
+	for _, card := range cards {
+		if len(result) >= limit {
+			break
+		}
+		if seen[card.WordID] {
+			continue
+		}
+		seen[card.WordID] = true
+		result = append(result, card)
+	}
+	return result
+}
+
+// dayStart 返回now所在学习日的起始时间
+func (s *WordService) dayStart(now time.Time) time.Time {
+	start := time.Date(now.Year(), now.Month(), now.Day(), s.settings.DayStartHour, 0, 0, 0, now.Location())
+	if now.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// countReviewsSince 统计自since起处于指定状态时作答的次数
+func (s *WordService) countReviewsSince(since time.Time, state string) int {
+	var count int64
+	s.db.Model(&models.ReviewLog{}).
+		Where("profile_id = ? AND reviewed_at >= ? AND state = ?", s.profileID, since.Unix(), state).
+		Count(&count)
+	return int(count)
+}
+
+// sortReviews 按设置的复习排序方式排列待复习卡片
+func (s *WordService) sortReviews(cards []models.Card, now time.Time) {
+	switch s.settings.ReviewOrder {
+	case models.ReviewOrderOverdue:
+		sort.SliceStable(cards, func(i, j int) bool {
+			return overdueness(cards[i].ReviewState, now) > overdueness(cards[j].ReviewState, now)
+		})
+	case models.ReviewOrderRetrievability:
+		sort.SliceStable(cards, func(i, j int) bool {
+			return retrievabilityAt(cards[i].ReviewState, now) < retrievabilityAt(cards[j].ReviewState, now)
+		})
+	default:
+		sort.SliceStable(cards, func(i, j int) bool {
+			return cards[i].NextReview < cards[j].NextReview
+		})
+	}
+}
+
+// overdueness 计算相对逾期程度：逾期天数 / 复习间隔
+func overdueness(state models.ReviewState, now time.Time) float64 {
+	overdueDays := now.Sub(time.Unix(state.NextReview, 0)).Hours() / 24
+	return overdueDays / float64(max(state.Interval, 1))
+}
+
+// retrievabilityAt 估算now时刻的回忆概率
+// 没有FSRS稳定性的卡片以复习间隔近似稳定性
+func retrievabilityAt(state models.ReviewState, now time.Time) float64 {
+	stability := state.Stability
+	if stability <= 0 {
+		stability = float64(max(state.Interval, 1))
+	}
+	elapsedDays := now.Sub(time.Unix(state.LastReviewed, 0)).Hours() / 24
+	return Retrievability(max(elapsedDays, 0), stability)
+}
+
+// mixNewCards 按混合方式将新卡片与复习卡片合并
+func mixNewCards(reviews []models.Card, newCards []models.Card, mix string) []models.Card {
+	result := make([]models.Card, 0, len(reviews)+len(newCards))
+	switch {
+	case mix == models.NewCardMixBefore:
+		result = append(append(result, newCards...), reviews...)
+	case mix == models.NewCardMixAfter || len(newCards) == 0:
+		result = append(append(result, reviews...), newCards...)
+	default:
+		// 每隔固定数量的复习插入一张新卡片
+		gap := float64(len(reviews)+len(newCards)) / float64(len(newCards))
+		next, r, n := gap/2, 0, 0
+		for r < len(reviews) || n < len(newCards) {
+			if n < len(newCards) && (r >= len(reviews) || float64(len(result)) >= next) {
+				result = append(result, newCards[n])
+				n++
+				next += gap
+			} else {
+				result = append(result, reviews[r])
+				r++
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"WordMaster/models"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// GetTags 获取所有标签及其单词数量
+func (s *WordService) GetTags() []models.Tag {
+	var tags []models.Tag
+	s.db.Order("name").Find(&tags)
+
+	var counts []struct {
+		TagID int
+		Count int
+	}
+	s.db.Table("word_tags").Select("tag_id, COUNT(*) AS count").
+		Where("word_id NOT IN " + trashedWordIDs).Group("tag_id").Scan(&counts)
+	wordCounts := make(map[int]int, len(counts))
+	for _, c := range counts {
+		wordCounts[c.TagID] = c.Count
+	}
+	for i := range tags {
+		tags[i].WordCount = wordCounts[tags[i].ID]
+	}
+	return tags
+}
+
+// TagWords 为单词批量添加标签，不存在的标签会自动创建
+func (s *WordService) TagWords(wordIDs []int, tags []string) error {
+	names, err := normalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return addWordTags(tx, wordIDs, names)
+	})
+}
+
+// UntagWords 批量移除单词的标签，不再被使用的标签一并删除
+func (s *WordService) UntagWords(wordIDs []int, tags []string) error {
+	names, err := normalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	if len(wordIDs) == 0 || len(names) == 0 {
+		return nil
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM word_tags WHERE word_id IN ? AND tag_id IN (SELECT id FROM tags WHERE name IN ?)",
+			wordIDs, names).Error; err != nil {
+			return err
+		}
+		return deleteUnusedTags(tx)
+	})
+}
+
+// addWordTags 在事务中为单词添加标签，names 需已规范化
+func addWordTags(tx *gorm.DB, wordIDs []int, names []string) error {
+	if len(wordIDs) == 0 || len(names) == 0 {
+		return nil
+	}
+	for _, name := range names {
+		if err := tx.Exec("INSERT OR IGNORE INTO tags (name) VALUES (?)", name).Error; err != nil {
+			return err
+		}
+	}
+
+	var tags []models.Tag
+	if err := tx.Where("name IN ?", names).Find(&tags).Error; err != nil {
+		return err
+	}
+	for _, wordID := range wordIDs {
+		for _, tag := range tags {
+			if err := tx.Exec("INSERT OR IGNORE INTO word_tags (tag_id, word_id) VALUES (?, ?)", tag.ID, wordID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteUnusedTags 删除没有任何单词的标签
+func deleteUnusedTags(tx *gorm.DB) error {
+	return tx.Exec("DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM word_tags)").Error
+}
+
+// tagNames 返回标签名称列表
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// normalizeTags 规范化标签名：去除首尾空白并转为小写，去除重复和空标签
+// 标签名不能包含空白，以便在查询语句中使用
+func normalizeTags(tags []string) ([]string, error) {
+	var names []string
+	for _, tag := range tags {
+		name := strings.ToLower(strings.TrimSpace(tag))
+		if name == "" || slices.Contains(names, name) {
+			continue
+		}
+		if strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+			return nil, fmt.Errorf("tag '%s' cannot contain whitespace", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
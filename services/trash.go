@@ -0,0 +1,103 @@
+package services
+
+import (
+	"WordMaster/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// trashedWordIDs 回收站中单词ID的子查询，用于排除不经过words表的查询(卡片、单词本和标签统计等)
+const trashedWordIDs = "(SELECT id FROM words WHERE deleted_at IS NOT NULL)"
+
+// ListTrash 获取回收站中的单词，最近删除的排在前面
+func (s *WordService) ListTrash() []models.TrashedWord {
+	var words []models.Word
+	preloadSenses(s.db.Unscoped()).Preload("Tags").
+		Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&words)
+
+	trashed := make([]models.TrashedWord, len(words))
+	for i, word := range words {
+		trashed[i] = models.TrashedWord{Word: word, DeletedAt: word.DeletedAt.Time.Unix()}
+	}
+	return trashed
+}
+
+// RestoreWord 将单词从回收站恢复，学习进度、单词本和标签随之恢复
+func (s *WordService) RestoreWord(id int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.Word{}).Where("id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("word %d is not in the trash", id)
+		}
+
+		// 单词在回收站期间新建的学习者还没有该单词的卡片
+		var word models.Word
+		if err := tx.First(&word, id).Error; err != nil {
+			return err
+		}
+		var existing []string
+		if err := s.profileCards(tx).Where("word_id = ?", id).Pluck("card_type", &existing).Error; err != nil {
+			return err
+		}
+		return s.ensureWordCards(tx, word, existing)
+	})
+}
+
+// PurgeTrash 彻底删除回收站中超过 olderThanDays 天的单词及其卡片、复习记录和关系，0表示清空回收站
+// 返回删除的单词数
+func (s *WordService) PurgeTrash(olderThanDays int) (int, error) {
+	cutoff := time.Now().AddDate(0, 0, -max(olderThanDays, 0))
+	var ids []int
+	if err := s.db.Unscoped().Model(&models.Word{}).
+		Where("deleted_at IS NOT NULL AND deleted_at <= ?", cutoff).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			if err := purgeWord(tx, id); err != nil {
+				return err
+			}
+		}
+		return deleteUnusedTags(tx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// purgeWord 在事务中彻底删除单词及其义项、卡片、复习记录、单词关系、修订历史、单词本和标签关系
+func purgeWord(tx *gorm.DB, id int) error {
+	if err := tx.Where("word_id = ?", id).Delete(&models.Card{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("word_id = ?", id).Delete(&models.ReviewLog{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM deck_words WHERE word_id = ?", id).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM word_tags WHERE word_id = ?", id).Error; err != nil {
+		return err
+	}
+	if err := deleteSenses(tx, id); err != nil {
+		return err
+	}
+	if err := tx.Where("word_id = ? OR related_id = ?", id, id).Delete(&models.WordRelation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("word_id = ?", id).Delete(&models.WordRevision{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&models.Word{}, id).Error
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// errWordExists 单词已存在，AddWord 返回的错误信息为 "word 'xxx' already exists"
+var errWordExists = errors.New("already exists")
+
+// normalizeWordText 去掉单词首尾空白，并将中间连续的空白合并为一个空格
+func normalizeWordText(word string) string {
+	return strings.Join(strings.Fields(word), " ")
+}
+
+// wordKey 返回单词的唯一键，大小写和空白不同的单词(如 "Apple" 和 "apple ")视为同一个单词
+func wordKey(word string) string {
+	return strings.ToLower(normalizeWordText(word))
+}
+
+// cardV7 第7版的卡片表(加入学习者)，合并重复单词时只使用其中比较进度的列
+type cardV7 struct {
+	ID           int
+	ProfileID    int
+	WordID       int
+	CardType     string
+	State        string
+	LastReviewed int64
+	ReviewCount  int
+	Interval     int
+}
+
+// TableName 指定卡片表名
+func (cardV7) TableName() string {
+	return "cards"
+}
+
+// wordRelationV8 第8版的单词关系表
+type wordRelationV8 struct {
+	ID        int
+	WordID    int
+	RelatedID int
+	Type      string
+}
+
+// TableName 指定单词关系表名
+func (wordRelationV8) TableName() string {
+	return "word_relations"
+}
+
+// migrateWordKeys 为单词生成唯一键并合并已有的重复单词，最后创建唯一索引
+func migrateWordKeys(tx *gorm.DB) error {
+	if err := addColumn(tx, "words", "word_key", "text"); err != nil {
+		return err
+	}
+
+	var words []struct {
+		ID   int
+		Word string
+	}
+	if err := tx.Table("words").Select("id", "word").Order("id").Find(&words).Error; err != nil {
+		return err
+	}
+	var keys []string
+	duplicates := make(map[string][]int)
+	for _, word := range words {
+		text := normalizeWordText(word.Word)
+		key := wordKey(text)
+		if err := tx.Exec("UPDATE words SET word = ?, word_key = ? WHERE id = ?", text, key, word.ID).Error; err != nil {
+			return err
+		}
+		if _, ok := duplicates[key]; !ok {
+			keys = append(keys, key)
+		}
+		duplicates[key] = append(duplicates[key], word.ID)
+	}
+
+	// 保留最早添加的单词，其余合并到该单词
+	for _, key := range keys {
+		if ids := duplicates[key]; len(ids) > 1 {
+			if err := mergeDuplicateWords(tx, ids[0], ids[1:]); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_words_key ON words (word_key)").Error
+}
+
+// mergeDuplicateWords 将重复单词合并到 keepID 后删除
+// 每个学习者的每种卡片保留进度最好的一张，复习记录、单词本、标签和单词关系归入保留的单词，
+// 词条内容和义项以保留的单词为准
+func mergeDuplicateWords(tx *gorm.DB, keepID int, duplicateIDs []int) error {
+	var cards []cardV7
+	if err := tx.Where("word_id IN ?", append([]int{keepID}, duplicateIDs...)).Order("id").Find(&cards).Error; err != nil {
+		return err
+	}
+	type cardSlot struct {
+		profileID int
+		cardType  string
+	}
+	best := make(map[cardSlot]cardV7)
+	for _, card := range cards {
+		slot := cardSlot{card.ProfileID, card.CardType}
+		if current, ok := best[slot]; !ok || betterProgress(card, current) {
+			best[slot] = card
+		}
+	}
+	for _, card := range cards {
+		if best[cardSlot{card.ProfileID, card.CardType}].ID != card.ID {
+			if err := tx.Exec("DELETE FROM cards WHERE id = ?", card.ID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	for _, card := range best {
+		if card.WordID != keepID {
+			if err := tx.Exec("UPDATE cards SET word_id = ? WHERE id = ?", keepID, card.ID).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Exec("UPDATE review_logs SET word_id = ? WHERE word_id IN ?", keepID, duplicateIDs).Error; err != nil {
+		return err
+	}
+	for _, join := range []struct{ table, column string }{{"deck_words", "deck_id"}, {"word_tags", "tag_id"}} {
+		if err := tx.Exec("INSERT OR IGNORE INTO "+join.table+" ("+join.column+", word_id) SELECT "+join.column+", ? FROM "+join.table+" WHERE word_id IN ?",
+			keepID, duplicateIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM "+join.table+" WHERE word_id IN ?", duplicateIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	// 单词关系改为保留的单词后重新添加：对称关系只保存 word_id < related_id 的一条，派生自关系保持方向
+	var relations []wordRelationV8
+	if err := tx.Where("word_id IN ? OR related_id IN ?", duplicateIDs, duplicateIDs).Find(&relations).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM word_relations WHERE word_id IN ? OR related_id IN ?", duplicateIDs, duplicateIDs).Error; err != nil {
+		return err
+	}
+	for _, relation := range relations {
+		if slices.Contains(duplicateIDs, relation.WordID) {
+			relation.WordID = keepID
+		}
+		if slices.Contains(duplicateIDs, relation.RelatedID) {
+			relation.RelatedID = keepID
+		}
+		if relation.WordID == relation.RelatedID {
+			continue
+		}
+		if relation.Type != "derivedFrom" && relation.WordID > relation.RelatedID {
+			relation.WordID, relation.RelatedID = relation.RelatedID, relation.WordID
+		}
+		if err := tx.Exec("INSERT OR IGNORE INTO word_relations (word_id, related_id, type) VALUES (?, ?, ?)",
+			relation.WordID, relation.RelatedID, relation.Type).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, statement := range []string{
+		"DELETE FROM sense_examples WHERE sense_id IN (SELECT id FROM senses WHERE word_id IN ?)",
+		"DELETE FROM senses WHERE word_id IN ?",
+		"DELETE FROM words WHERE id IN ?",
+	} {
+		if err := tx.Exec(statement, duplicateIDs).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// betterProgress 判断卡片a的学习进度是否好于b：已学习优先，其次比较复习间隔、复习次数和上次复习时间
+func betterProgress(a, b cardV7) bool {
+	if (a.State != "new") != (b.State != "new") {
+		return a.State != "new"
+	}
+	if a.Interval != b.Interval {
+		return a.Interval > b.Interval
+	}
+	if a.ReviewCount != b.ReviewCount {
+		return a.ReviewCount > b.ReviewCount
+	}
+	return a.LastReviewed > b.LastReviewed
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"WordMaster/models"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// legacyWordsTable 最早版本的 words 表，学习进度直接保存在单词上
+const legacyWordsTable = "CREATE TABLE `words` (`id` integer,`word` text,`phonetic` text,`pronunciation` text,`definition` text,`example` text,`translation` text,`image_url` text,`difficulty` integer,`last_reviewed` integer,`next_review` integer,`review_count` integer,`ease_factor` real,`interval` integer,`learned` numeric,`mastered` numeric,PRIMARY KEY (`id`))"
+
+func TestMigrateLegacyDatabase(t *testing.T) {
+	dir := t.TempDir()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "words.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(legacyWordsTable).Error; err != nil {
+		t.Fatal(err)
+	}
+	// 只差大小写和空白的重复单词，第二个的学习进度更好
+	err = db.Exec("INSERT INTO words (word, definition, example, interval, ease_factor, review_count, learned) VALUES " +
+		"('apple', '苹果', '', 0, 2.5, 0, 0), ('Apple ', 'n. 苹果', 'An apple.', 3, 2.6, 2, 1)").Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := closeDB(db); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewWordService(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	var words []models.Word
+	if err := s.db.Find(&words).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(words) != 1 {
+		t.Fatalf("got %d words, want 1", len(words))
+	}
+	word := words[0]
+	if word.ID != 1 || word.Word != "apple" || word.Key != "apple" {
+		t.Errorf("merged word = %d %q key %q, want 1 \"apple\" key \"apple\"", word.ID, word.Word, word.Key)
+	}
+
+	var cards []models.Card
+	if err := s.db.Where("card_type = ?", models.CardTypeRecognition).Find(&cards).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 1 {
+		t.Fatalf("got %d recognition cards, want 1", len(cards))
+	}
+	card := cards[0]
+	if card.WordID != word.ID || card.ProfileID != s.profileID {
+		t.Errorf("card belongs to word %d profile %d, want word %d profile %d", card.WordID, card.ProfileID, word.ID, s.profileID)
+	}
+	if card.State != models.StateReview || card.Interval != 3 || card.EaseFactor != 2.6 || card.ReviewCount != 2 {
+		t.Errorf("kept card state %s interval %d ease %v reviews %d, want the progress of 'Apple '",
+			card.State, card.Interval, card.EaseFactor, card.ReviewCount)
+	}
+
+	for _, column := range []string{"interval", "ease_factor", "review_count", "learned"} {
+		if s.db.Migrator().HasColumn("words", column) {
+			t.Errorf("legacy column %s was not dropped", column)
+		}
+	}
+	if !s.db.Migrator().HasIndex("words", "idx_words_key") {
+		t.Fatal("unique index idx_words_key is missing")
+	}
+	if err := s.db.Exec("INSERT INTO words (word, word_key) VALUES ('APPLE', 'apple')").Error; err == nil {
+		t.Error("inserting a duplicate word key succeeded")
+	}
+}
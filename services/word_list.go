@@ -0,0 +1,103 @@
+package services
+
+import (
+	"WordMaster/models"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 单词列表分页大小
+const (
+	defaultWordPageSize = 50
+	maxWordPageSize     = 500
+)
+
+// wordSortColumns 各排序方式对应的排序列，rc 为单词的识别卡片
+var wordSortColumns = map[string]string{
+	models.WordSortAlphabetical: "words.word COLLATE NOCASE",
+	models.WordSortNextReview:   "rc.next_review",
+	models.WordSortEase:         "rc.ease_factor",
+	models.WordSortCreated:      "words.created_at",
+}
+
+// ListWords 分页获取单词列表，支持排序、筛选和查询语句，并返回符合条件的单词总数
+func (s *WordService) ListWords(params models.WordListParams) (models.WordPage, error) {
+	if params.Sort == "" {
+		params.Sort = models.WordSortCreated
+	}
+	column, ok := wordSortColumns[params.Sort]
+	if !ok {
+		return models.WordPage{}, fmt.Errorf("unknown sort '%s'", params.Sort)
+	}
+	if params.Limit <= 0 {
+		params.Limit = defaultWordPageSize
+	}
+	params.Limit = min(params.Limit, maxWordPageSize)
+	params.Offset = max(params.Offset, 0)
+
+	// 单词级筛选以识别卡片代表单词的学习进度
+	query := s.db.Model(&models.Word{}).
+		Joins("LEFT JOIN cards rc ON rc.word_id = words.id AND rc.card_type = ? AND rc.profile_id = ?",
+			models.CardTypeRecognition, s.profileID)
+	query, err := s.applyWordQuery(query, params.Query)
+	if err != nil {
+		return models.WordPage{}, err
+	}
+	if params.Learned != nil {
+		if *params.Learned {
+			query = query.Where("rc.state <> ?", models.StateNew)
+		} else {
+			query = query.Where("rc.state = ? OR rc.id IS NULL", models.StateNew)
+		}
+	}
+	if params.Mastered != nil {
+		if *params.Mastered {
+			query = query.Where("rc.interval >= ?", models.MasteredInterval)
+		} else {
+			query = query.Where("rc.interval < ? OR rc.id IS NULL", models.MasteredInterval)
+		}
+	}
+	if params.Due {
+		query = query.Where("rc.suspended = ? AND rc.state <> ? AND rc.next_review <= ?",
+			false, models.StateNew, time.Now().Unix())
+	}
+	if params.Difficulty > 0 {
+		query = query.Where("words.difficulty = ?", params.Difficulty)
+	}
+
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return models.WordPage{}, err
+	}
+
+	direction := " ASC"
+	if params.Descending {
+		direction = " DESC"
+	}
+	order := column + direction + ", words.id" + direction
+	if params.Sort == models.WordSortNextReview {
+		// 未学习的单词没有复习时间，无论正序倒序都排在最后
+		order = "rc.state = 'new', " + order
+	}
+
+	var words []models.Word
+	err = preloadSenses(query).Preload("Tags").Select("words.*").
+		Order(order).
+		Limit(params.Limit).Offset(params.Offset).
+		Find(&words).Error
+	if err != nil {
+		return models.WordPage{}, err
+	}
+	s.fillProgress(words)
+
+	return models.WordPage{
+		Words:  words,
+		Total:  int(total),
+		Offset: params.Offset,
+		Limit:  params.Limit,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"WordMaster/models"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// queryTerm 表示查询语句中的一个条件
+type queryTerm struct {
+	negate bool   // 是否取反
+	field  string // 条件字段，为空表示匹配单词文本
+	value  string // 条件值
+}
+
+// queryFields 查询语句支持的条件字段
+var queryFields = []string{"tag", "deck", "due", "state", "is"}
+
+// parseWordQuery 将查询语句拆分为条件，如 `tag:verb -tag:easy deck:"ielts 7" due:today`
+// 值中包含空白时可用双引号括起
+func parseWordQuery(query string) ([]queryTerm, error) {
+	var tokens []string
+	var token strings.Builder
+	quoted, hasToken := false, false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			hasToken = true
+		case unicode.IsSpace(r) && !quoted:
+			if hasToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				hasToken = false
+			}
+		default:
+			token.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in query")
+	}
+	if hasToken {
+		tokens = append(tokens, token.String())
+	}
+
+	terms := make([]queryTerm, 0, len(tokens))
+	for _, token := range tokens {
+		var term queryTerm
+		if len(token) > 1 && token[0] == '-' {
+			term.negate = true
+			token = token[1:]
+		}
+		term.value = token
+		if field, value, ok := strings.Cut(token, ":"); ok && slices.Contains(queryFields, strings.ToLower(field)) {
+			if value == "" {
+				return nil, fmt.Errorf("missing value for '%s:'", field)
+			}
+			term.field, term.value = strings.ToLower(field), value
+		}
+		if term.value == "" {
+			continue
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// QueryWords 按查询语句筛选单词，多个条件之间为"且"关系，条件前加 - 表示取反
+//
+//	tag:名称          带有该标签
+//	deck:名称         属于该单词本
+//	due:today         截至今天结束有到期的卡片；due:N 表示N天内到期
+//	state:状态        识别卡片处于该状态 (new/learning/review/relearning)
+//	is:suspended      有暂停的卡片；is:leech 为顽固词；is:mastered 已掌握
+//	其他文本          单词、释义或例句翻译中包含该文本
+func (s *WordService) QueryWords(query string) ([]models.Word, error) {
+	db, err := s.applyWordQuery(preloadSenses(s.db.Model(&models.Word{})).Preload("Tags"), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var words []models.Word
+	if err := db.Order("words.id").Find(&words).Error; err != nil {
+		return nil, err
+	}
+	s.fillProgress(words)
+	return words, nil
+}
+
+// applyWordQuery 将查询语句的条件加到单词查询上
+func (s *WordService) applyWordQuery(db *gorm.DB, query string) (*gorm.DB, error) {
+	terms, err := parseWordQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	for _, term := range terms {
+		condition, args, err := s.queryCondition(term)
+		if err != nil {
+			return nil, err
+		}
+		if term.negate {
+			condition = "NOT (" + condition + ")"
+		}
+		db = db.Where(condition, args...)
+	}
+	return db, nil
+}
+
+// queryCondition 将查询条件转换为SQL条件及参数
+func (s *WordService) queryCondition(term queryTerm) (string, []interface{}, error) {
+	switch term.field {
+	case "tag":
+		return "words.id IN (SELECT word_tags.word_id FROM word_tags JOIN tags ON tags.id = word_tags.tag_id WHERE tags.name = ?)",
+			[]interface{}{strings.ToLower(term.value)}, nil
+	case "deck":
+		return "words.id IN (SELECT deck_words.word_id FROM deck_words JOIN decks ON decks.id = deck_words.deck_id WHERE decks.name = ? COLLATE NOCASE)",
+			[]interface{}{term.value}, nil
+	case "due":
+		days := 0
+		if !strings.EqualFold(term.value, "today") {
+			n, err := strconv.Atoi(term.value)
+			if err != nil || n < 0 {
+				return "", nil, fmt.Errorf("invalid due value '%s', use 'today' or a number of days", term.value)
+			}
+			days = n
+		}
+		cutoff := s.dayStart(time.Now()).AddDate(0, 0, days+1).Unix()
+		return "words.id IN (SELECT word_id FROM cards WHERE profile_id = ? AND suspended = ? AND card_type IN ? AND state <> ? AND next_review < ?)",
+			[]interface{}{s.profileID, false, s.enabledCardTypes(), models.StateNew, cutoff}, nil
+	case "state":
+		state := strings.ToLower(term.value)
+		if !slices.Contains([]string{models.StateNew, models.StateLearning, models.StateReview, models.StateRelearning}, state) {
+			return "", nil, fmt.Errorf("unknown state '%s'", term.value)
+		}
+		return "words.id IN (SELECT word_id FROM cards WHERE profile_id = ? AND card_type = ? AND state = ?)",
+			[]interface{}{s.profileID, models.CardTypeRecognition, state}, nil
+	case "is":
+		switch strings.ToLower(term.value) {
+		case "suspended":
+			return "words.id IN (SELECT word_id FROM cards WHERE profile_id = ? AND suspended = ?)",
+				[]interface{}{s.profileID, true}, nil
+		case "leech":
+			return "words.id IN (SELECT word_id FROM cards WHERE profile_id = ? AND leech = ?)",
+				[]interface{}{s.profileID, true}, nil
+		case "mastered":
+			return "words.id IN (SELECT word_id FROM cards WHERE profile_id = ? AND card_type = ? AND interval >= ?)",
+				[]interface{}{s.profileID, models.CardTypeRecognition, models.MasteredInterval}, nil
+		}
+		return "", nil, fmt.Errorf("unknown value 'is:%s'", term.value)
+	}
+
+	pattern := "%" + escapeLike(term.value) + "%"
+	return `(words.word LIKE ? ESCAPE '\' OR words.definition LIKE ? ESCAPE '\' OR words.translation LIKE ? ESCAPE '\')`,
+		[]interface{}{pattern, pattern, pattern}, nil
+}
+
+// escapeLike 转义LIKE模式中的通配符
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseWordQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    []queryTerm
+		wantErr bool
+	}{
+		{`tag:verb -tag:easy deck:"ielts 7" due:today`, []queryTerm{
+			{field: "tag", value: "verb"},
+			{negate: true, field: "tag", value: "easy"},
+			{field: "deck", value: "ielts 7"},
+			{field: "due", value: "today"},
+		}, false},
+		{"  apple   Tag:Verb ", []queryTerm{
+			{value: "apple"},
+			{field: "tag", value: "Verb"},
+		}, false},
+		{`"ice cream" -apple`, []queryTerm{
+			{value: "ice cream"},
+			{negate: true, value: "apple"},
+		}, false},
+		{"time:10 - http://example.com", []queryTerm{
+			{value: "time:10"},
+			{value: "-"},
+			{value: "http://example.com"},
+		}, false},
+		{`"" is:leech`, []queryTerm{{field: "is", value: "leech"}}, false},
+		{"", []queryTerm{}, false},
+		{`deck:"ielts`, nil, true},
+		{"tag:", nil, true},
+		{"-state:", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseWordQuery(tt.query)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseWordQuery(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseWordQuery(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
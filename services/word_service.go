@@ -3,19 +3,29 @@ package services
 import (
 	"WordMaster/models"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"math"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // WordService 单词服务
 type WordService struct {
-	db *gorm.DB
+	db        *gorm.DB
+	dataDir   string // 数据目录
+	settings  models.StudySettings
+	scheduler Scheduler
+	profileID int        // 当前学习者ID
+	fts       bool       // 是否可以使用FTS5全文索引
+	backupMu  sync.Mutex // 备份和恢复不能同时进行
 }
 
 // NewWordService 创建一个新的WordService实例
@@ -25,156 +35,578 @@ func NewWordService(dataDir string) (*WordService, error) {
 		return nil, err
 	}
 
+	service := &WordService{
+		dataDir: dataDir,
+	}
+	if err := service.open(); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// open 打开数据目录中的数据库，执行迁移并加载当前学习者和学习设置
+func (s *WordService) open() error {
 	// 初始化数据库
-	dbPath := filepath.Join(dataDir, "words.db")
-	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open(s.dbPath()), &gorm.Config{})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// 自动迁移数据库结构
-	if err := db.AutoMigrate(&models.Word{}); err != nil {
-		return nil, err
+	// 按版本执行数据库迁移
+	if err := runMigrations(db, s.dataDir); err != nil {
+		closeDB(db)
+		return err
+	}
+
+	s.db = db
+	if err := s.load(); err != nil {
+		// 加载失败时关闭数据库，不留下初始化了一半的服务
+		closeDB(db)
+		s.db = nil
+		return err
+	}
+	return nil
+}
+
+// load 读取当前学习者及其学习设置，并补全卡片和全文索引
+func (s *WordService) load() error {
+	if err := s.loadActiveProfile(); err != nil {
+		return err
+	}
+	if err := s.loadStudySettings(); err != nil {
+		return err
+	}
+
+	// 为每个单词生成启用的卡片
+	if err := s.ensureCards(); err != nil {
+		return err
 	}
 
-	return &WordService{
-		db: db,
-	}, nil
+	// 全文索引依赖编译选项，不作为版本迁移执行
+	return s.setupSearchIndex()
+}
+
+// dbPath 返回数据库文件路径
+func (s *WordService) dbPath() string {
+	return filepath.Join(s.dataDir, "words.db")
+}
+
+// closeDB 关闭数据库连接
+func closeDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
 
 // AddWord 添加新单词
+// 单词去掉首尾空白后保存，大小写和空白不同的单词视为已存在
 func (s *WordService) AddWord(word models.Word) (models.Word, error) {
-	// 检查单词是否已存在
+	return s.addWord(word, models.RevisionSourceManual)
+}
+
+// addWord 添加新单词，并以 source 为来源记录第一条修订
+func (s *WordService) addWord(word models.Word, source string) (models.Word, error) {
+	word.Word = normalizeWordText(word.Word)
+	word.Key = wordKey(word.Word)
+	if word.Key == "" {
+		return models.Word{}, errors.New("word cannot be empty")
+	}
+
+	// 检查单词是否已存在(包括回收站中的单词)
 	var existingWord models.Word
-	result := s.db.Where("word = ?", word.Word).First(&existingWord)
+	result := s.db.Unscoped().Where("word_key = ?", word.Key).First(&existingWord)
 	if result.Error == nil {
 		// 单词已存在
-		return existingWord, fmt.Errorf("word '%s' already exists", word.Word)
+		if existingWord.DeletedAt.Valid {
+			return existingWord, fmt.Errorf("word '%s' %w in the trash", word.Word, errWordExists)
+		}
+		return existingWord, fmt.Errorf("word '%s' %w", word.Word, errWordExists)
 	}
 
-	// 设置初始学习参数
-	word.EaseFactor = 2.5
-	word.Interval = 1
-	word.ReviewCount = 0
-	word.LastReviewed = time.Now().Unix()
-	word.NextReview = time.Now().Add(24 * time.Hour).Unix()
+	// 创建新单词及其卡片，传入的卡片(如导入的备份)保留原有进度
+	// 单词本通过 AddWordsToDeck 单独维护
+	tags, err := normalizeTags(tagNames(word.Tags))
+	if err != nil {
+		return models.Word{}, err
+	}
+	normalizeSenses(&word)
+	// ID由数据库分配，导入文件中的ID不沿用
+	word.ID = 0
+	cards := word.Cards
+	word.Cards = nil
+	word.Decks = nil
+	word.Tags = nil
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 同时导入时检查之后可能已有相同的单词，由唯一索引保证不重复
+		result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&word)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errWordExists
+		}
+		if err := saveSenses(tx, word); err != nil {
+			return err
+		}
+		if err := recordRevision(tx, word.ID, map[string]string{}, wordContent(word), source, 0); err != nil {
+			return err
+		}
+		if err := addWordTags(tx, []int{word.ID}, tags); err != nil {
+			return err
+		}
 
-	// 创建新单词
-	if err := s.db.Create(&word).Error; err != nil {
+		var existing []string
+		for _, card := range cards {
+			if cardTypeOrd(card.CardType) < 0 || slices.Contains(existing, card.CardType) {
+				continue
+			}
+			card.ID = 0
+			card.ProfileID = s.profileID
+			card.WordID = word.ID
+			card.Ord = cardTypeOrd(card.CardType)
+			card.Word = nil
+			if err := tx.Create(&card).Error; err != nil {
+				return err
+			}
+			existing = append(existing, card.CardType)
+		}
+		return s.ensureWordCards(tx, word, existing)
+	})
+	if errors.Is(err, errWordExists) {
+		s.db.Unscoped().Where("word_key = ?", word.Key).First(&existingWord)
+		return existingWord, fmt.Errorf("word '%s' %w", word.Word, errWordExists)
+	}
+	if err != nil {
 		return models.Word{}, err
 	}
 
+	s.db.Model(&word).Association("Tags").Find(&word.Tags)
+	s.fillProgress([]models.Word{word})
 	return word, nil
 }
 
 // GetAllWords 获取所有单词
 func (s *WordService) GetAllWords() []models.Word {
 	var words []models.Word
-	s.db.Find(&words)
+	preloadSenses(s.db).Preload("Tags").Find(&words)
+	s.fillProgress(words)
 	return words
 }
 
 // GetWordByID 根据ID获取单词
 func (s *WordService) GetWordByID(id int) (models.Word, error) {
 	var word models.Word
-	result := s.db.First(&word, id)
+	result := preloadSenses(s.db).Preload("Tags").First(&word, id)
 	if result.Error != nil {
 		return models.Word{}, result.Error
 	}
-	return word, nil
+	words := []models.Word{word}
+	s.fillProgress(words)
+	s.fillRelated(words)
+	return words[0], nil
 }
 
-// UpdateWord 更新单词
+// UpdateWord 更新单词的词条内容和义项，不会修改卡片上的学习进度
+// 例句变化后可能需要补充填空卡片；修改的字段记录在修订历史中
 func (s *WordService) UpdateWord(word models.Word) error {
-	return s.db.Save(&word).Error
+	return s.updateWord(word, models.RevisionSourceManual, 0)
 }
 
-// DeleteWord 删除单词
+// updateWord 更新单词内容，并以 source 为来源记录修订，revertedTo 为恢复到的修订ID
+func (s *WordService) updateWord(word models.Word, source string, revertedTo int) error {
+	word.Word = normalizeWordText(word.Word)
+	word.Key = wordKey(word.Word)
+	if word.Key == "" {
+		return errors.New("word cannot be empty")
+	}
+	var old models.Word
+	if err := preloadSenses(s.db).Limit(1).Find(&old, word.ID).Error; err != nil {
+		return err
+	}
+	if old.ID == 0 {
+		return fmt.Errorf("word %d not found", word.ID)
+	}
+	// 回收站中的单词也占用唯一键
+	var count int64
+	if err := s.db.Unscoped().Model(&models.Word{}).Where("word_key = ? AND id <> ?", word.Key, word.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("word '%s' %w", word.Word, errWordExists)
+	}
+
+	normalizeSenses(&word)
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations, "CreatedAt").Save(&word).Error; err != nil {
+			return err
+		}
+		if err := saveSenses(tx, word); err != nil {
+			return err
+		}
+		if err := recordRevision(tx, word.ID, wordContent(old), wordContent(word), source, revertedTo); err != nil {
+			return err
+		}
+
+		var existing []string
+		if err := s.profileCards(tx).Where("word_id = ?", word.ID).
+			Pluck("card_type", &existing).Error; err != nil {
+			return err
+		}
+		return s.ensureWordCards(tx, word, existing)
+	})
+}
+
+// DeleteWord 将单词移入回收站，学习进度、单词本和标签保留，恢复后可继续学习
+// 回收站中的单词不出现在任何查询中，由 PurgeTrash 彻底删除
 func (s *WordService) DeleteWord(id int) error {
-	return s.db.Delete(&models.Word{}, id).Error
+	result := s.db.Delete(&models.Word{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("word %d not found", id)
+	}
+	return nil
 }
 
 // GetWordsForReview 获取需要复习的单词
-func (s *WordService) GetWordsForReview() []models.Word {
-	var words []models.Word
-	now := time.Now().Unix()
-	s.db.Where("next_review <= ?", now).Find(&words)
+// 包括识别卡片已到期的重新学习单词，以及按复习排序方式排列、不超过今日剩余复习上限的复习单词；
+// 没有到期单词时，提前取回在 LearnAheadLimit 窗口内到期的重新学习单词
+// deckID 不为0时只返回该单词本中的单词
+func (s *WordService) GetWordsForReview(deckID int) []models.Word {
+	now := time.Now()
+	relearning := func(cutoff int64) []models.Card {
+		var cards []models.Card
+		s.recognitionCards(deckID).
+			Where("cards.state = ? AND cards.next_review <= ?", models.StateRelearning, cutoff).
+			Order("cards.next_review").Find(&cards)
+		return cards
+	}
+
+	var reviews []models.Card
+	if limit := s.dailyBudget(now).ReviewLimit; limit > 0 {
+		s.recognitionCards(deckID).
+			Where("cards.state = ? AND cards.next_review <= ? AND cards.buried_until <= ?",
+				models.StateReview, now.Unix(), now.Unix()).
+			Find(&reviews)
+		s.sortReviews(reviews, now)
+		reviews = reviews[:min(len(reviews), limit)]
+	}
+
+	cards := append(relearning(now.Unix()), reviews...)
+	if len(cards) == 0 {
+		cards = relearning(s.learnAheadCutoff(now))
+	}
+	words := cardWords(cards)
+	s.fillProgress(words)
+	s.fillRelated(words)
 	return words
 }
 
 // GetNewWordsToLearn 获取新的待学习单词
-func (s *WordService) GetNewWordsToLearn(count int) []models.Word {
-	var words []models.Word
-	s.db.Where("review_count = 0").Limit(count).Find(&words)
+// 本次学习中再次到期的学习中单词排在前面，之后是新单词，
+// 新单词数量不超过count和今日剩余的新词上限；两者都没有时，
+// 提前取回在 LearnAheadLimit 窗口内到期的学习中单词
+// deckID 不为0时只返回该单词本中的单词
+func (s *WordService) GetNewWordsToLearn(count int, deckID int) []models.Word {
+	now := time.Now()
+	learning := func(cutoff int64) []models.Card {
+		var cards []models.Card
+		s.recognitionCards(deckID).
+			Where("cards.state = ? AND cards.next_review <= ?", models.StateLearning, cutoff).
+			Order("cards.next_review").Find(&cards)
+		return cards
+	}
+
+	var newCards []models.Card
+	if limit := min(count, s.dailyBudget(now).NewLimit); limit > 0 {
+		s.recognitionCards(deckID).
+			Where("cards.state = ? AND cards.buried_until <= ?", models.StateNew, now.Unix()).
+			Order("cards.word_id").Limit(limit).Find(&newCards)
+	}
+
+	cards := append(learning(now.Unix()), newCards...)
+	if len(cards) == 0 {
+		cards = learning(s.learnAheadCutoff(now))
+	}
+	words := cardWords(cards)
+	s.fillProgress(words)
+	s.fillRelated(words)
 	return words
 }
 
+// recognitionCards 返回当前学习者未暂停的识别卡片查询，并加载所属单词
+// 单词级接口以识别卡片代表单词本身的学习进度
+func (s *WordService) recognitionCards(deckID int) *gorm.DB {
+	query := s.profileCards(s.db).Preload("Word").
+		Where("cards.suspended = ? AND cards.card_type = ?", false, models.CardTypeRecognition)
+	return inDeck(query, deckID, "cards.word_id")
+}
+
+// cardWords 按卡片顺序返回卡片所属的单词
+func cardWords(cards []models.Card) []models.Word {
+	words := make([]models.Word, 0, len(cards))
+	for _, card := range cards {
+		if card.Word != nil {
+			words = append(words, *card.Word)
+		}
+	}
+	return words
+}
+
+// fillProgress 根据识别卡片填充单词的学习状态
+func (s *WordService) fillProgress(words []models.Word) {
+	if len(words) == 0 {
+		return
+	}
+	ids := make([]int, len(words))
+	for i, word := range words {
+		ids[i] = word.ID
+	}
+
+	var cards []models.Card
+	s.profileCards(s.db).Select("word_id", "state", "interval").
+		Where("card_type = ? AND word_id IN ?", models.CardTypeRecognition, ids).
+		Find(&cards)
+	progress := make(map[int]models.Card, len(cards))
+	for _, card := range cards {
+		progress[card.WordID] = card
+	}
+
+	for i := range words {
+		card, ok := progress[words[i].ID]
+		words[i].Learned = ok && card.State != models.StateNew
+		words[i].Mastered = ok && card.Interval >= models.MasteredInterval
+	}
+}
+
 // UpdateWordAfterReview 更新单词复习状态
 func (s *WordService) UpdateWordAfterReview(id int, quality int) error {
-	var word models.Word
-	if err := s.db.First(&word, id).Error; err != nil {
+	return s.ReviewWord(id, quality, 0)
+}
+
+// ReviewWord 更新单词识别卡片的复习状态并写入复习记录
+// responseTime 为作答用时(毫秒)，未知时传0
+func (s *WordService) ReviewWord(id int, quality int, responseTime int64) error {
+	cardID, err := s.recognitionCardID(id)
+	if err != nil {
 		return err
 	}
+	return s.ReviewCard(cardID, quality, responseTime)
+}
 
-	// 更新间隔重复参数
-	word.ReviewCount++
-	word.LastReviewed = time.Now().Unix()
+// GetReviewLogs 获取单词的复习记录，按时间倒序
+func (s *WordService) GetReviewLogs(wordID int) []models.ReviewLog {
+	var logs []models.ReviewLog
+	s.db.Where("profile_id = ? AND word_id = ?", s.profileID, wordID).Order("reviewed_at DESC").Find(&logs)
+	return logs
+}
 
-	// 计算新的间隔
-	if quality >= 3 {
-		if word.ReviewCount == 1 {
-			word.Interval = 1
-		} else if word.ReviewCount == 2 {
-			word.Interval = 6
-		} else {
-			word.Interval = int(math.Round(float64(word.Interval) * word.EaseFactor))
-		}
-		word.EaseFactor = word.EaseFactor + (0.1 - float64(5-quality)*(0.08+float64(5-quality)*0.02))
-	} else {
-		word.Interval = 1
-		word.ReviewCount = 0
+// schedulerFor 返回单词使用的调度器
+// 单词所在的单词本设置了调度算法时优先使用(多个单词本时取最早创建的)，否则使用全局设置
+func (s *WordService) schedulerFor(tx *gorm.DB, wordID int) Scheduler {
+	var name string
+	tx.Table("decks").Select("decks.scheduler").
+		Joins("JOIN deck_words ON deck_words.deck_id = decks.id").
+		Where("deck_words.word_id = ? AND decks.scheduler <> ''", wordID).
+		Order("decks.id").Limit(1).Scan(&name)
+	if name == "" || name == s.scheduler.Name() {
+		return s.scheduler
 	}
 
-	// 限制易度因子范围
-	if word.EaseFactor < 1.3 {
-		word.EaseFactor = 1.3
+	settings := s.settings
+	settings.Scheduler = name
+	scheduler, err := NewScheduler(settings)
+	if err != nil {
+		return s.scheduler
 	}
-
-	// 计算下次复习时间
-	word.NextReview = time.Now().Add(time.Duration(word.Interval) * 24 * time.Hour).Unix()
-
-	return s.db.Save(&word).Error
+	return scheduler
 }
 
 // ImportWords 从JSON文件导入单词
-func (s *WordService) ImportWords(filePath string) error {
+// 单词可以只包含 definition/example/translation，也可以包含 senses 义项列表；
+// related 中的关联单词按单词文本匹配词库中的单词，关系类型无效的跳过并在结果的 Warnings 中提示
+// 原形在词库或文件中的屈折变形(如 ran、running 之于 run)记录在结果中；
+// mergeForms 为true时没有自己释义的变形不单独添加，而是合并到原形(加入单词本、建立关联时使用原形)；
+// 有释义的变形仍单独添加，并在结果的 Warnings 中提示
+// deckID 不为0时将导入的单词(包括已存在的)加入该单词本
+func (s *WordService) ImportWords(filePath string, deckID int, mergeForms bool) (models.ImportResult, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return err
+		return models.ImportResult{}, err
 	}
 	defer file.Close()
 
 	var wordList models.WordList
 	if err := json.NewDecoder(file).Decode(&wordList); err != nil {
-		return err
+		return models.ImportResult{}, err
 	}
+	return s.importWordList(wordList.Words, deckID, mergeForms)
+}
 
-	for _, word := range wordList.Words {
-		if _, err := s.AddWord(word); err != nil {
-			// 如果单词已存在，跳过
-			if err.Error() == fmt.Sprintf("word '%s' already exists", word.Word) {
+// importWordList 导入单词列表，JSON和CSV导入共用，参数含义同 ImportWords
+func (s *WordService) importWordList(words []models.Word, deckID int, mergeForms bool) (models.ImportResult, error) {
+	result := models.ImportResult{Forms: []models.FormMatch{}, Errors: []models.RowError{}, Warnings: []string{}}
+
+	// 单词本不存在时不导入，以免单词已添加却没有加入单词本
+	if deckID != 0 {
+		var count int64
+		if err := s.db.Model(&models.Deck{}).Where("id = ?", deckID).Count(&count).Error; err != nil {
+			return result, err
+		}
+		if count == 0 {
+			return result, fmt.Errorf("deck %d not found", deckID)
+		}
+	}
+
+	// 关系类型无效的关联单词跳过并给出提示，以免单词添加后建立关联时才失败
+	for i, word := range words {
+		related := make([]models.RelatedWord, 0, len(word.Related))
+		for _, r := range word.Related {
+			if !slices.Contains(models.RelationTypes, r.Type) {
+				result.Warnings = append(result.Warnings, fmt.Sprintf(
+					"unknown relation type '%s' from '%s' to '%s', skipped", r.Type, word.Word, r.Word))
 				continue
 			}
+			related = append(related, r)
+		}
+		words[i].Related = related
+	}
+
+	// 导入前备份数据库，导入结果不符合预期时可以恢复
+	if _, err := s.backup(models.BackupReasonImport); err != nil {
+		return result, err
+	}
+
+	inFile := make(map[string]models.Word, len(words))
+	for _, word := range words {
+		inFile[wordKey(word.Word)] = word
+	}
+	lemmas := make([]string, len(words))
+	for i, word := range words {
+		var count int64
+		if err := s.db.Model(&models.Word{}).Where("word_key = ?", wordKey(word.Word)).Count(&count).Error; err != nil {
+			return result, err
+		}
+		if count > 0 {
+			continue
+		}
+		lemma, err := importLemma(s.db, word.Word, inFile)
+		if err != nil {
+			return result, err
+		}
+		lemmas[i] = lemma
+	}
+
+	imported := make([]int, len(words))
+	add := func(i int) error {
+		added, err := s.addWord(words[i], models.RevisionSourceImport)
+		if err != nil {
+			// 如果单词已存在，跳过
+			if errors.Is(err, errWordExists) {
+				imported[i] = added.ID
+				result.Existing++
+				return nil
+			}
 			return err
 		}
+		imported[i] = added.ID
+		result.Added++
+		return nil
 	}
 
-	return nil
+	// 有自己释义的变形不合并，以免丢失释义，单独添加并给出提示
+	merge := make([]bool, len(words))
+	for i, word := range words {
+		if !mergeForms || lemmas[i] == "" {
+			continue
+		}
+		if strings.TrimSpace(word.Definition) != "" || len(word.Senses) > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"'%s' looks like a form of '%s' but has its own definition, added separately", word.Word, lemmas[i]))
+			continue
+		}
+		merge[i] = true
+	}
+
+	// 先添加原形和其他单词，合并变形时原形已在词库中
+	for i := range words {
+		if merge[i] {
+			continue
+		}
+		if err := add(i); err != nil {
+			return result, err
+		}
+		if lemmas[i] != "" {
+			result.Forms = append(result.Forms, models.FormMatch{Word: words[i].Word, Lemma: lemmas[i]})
+		}
+	}
+	for i, word := range words {
+		if !merge[i] {
+			continue
+		}
+		var lemma models.Word
+		if err := s.db.Where("word_key = ?", wordKey(lemmas[i])).Limit(1).Find(&lemma).Error; err != nil {
+			return result, err
+		}
+		// 原形本身也作为变形被合并时(如 laid → lay → lie)，单独添加
+		if lemma.ID == 0 {
+			if err := add(i); err != nil {
+				return result, err
+			}
+			result.Forms = append(result.Forms, models.FormMatch{Word: word.Word, Lemma: lemmas[i]})
+			continue
+		}
+		imported[i] = lemma.ID
+		result.Merged++
+		result.Forms = append(result.Forms, models.FormMatch{Word: word.Word, Lemma: lemma.Word, Merged: true})
+	}
+
+	// 所有单词导入后再建立关联，关联单词可以出现在文件中的任意位置
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for i, word := range words {
+			if err := addImportedRelations(tx, imported[i], word.Related); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if deckID != 0 {
+		return result, s.AddWordsToDeck(deckID, imported)
+	}
+	return result, nil
 }
 
 // ExportWords 导出单词到JSON文件
-func (s *WordService) ExportWords(filePath string) error {
-	words := s.GetAllWords()
+// 同时包含汇总的释义、例句字段和义项列表，只识别旧格式的程序也能导入
+// includeProgress 为false时只导出词条内容，不包含卡片和学习进度
+func (s *WordService) ExportWords(filePath string, includeProgress bool) error {
+	var words []models.Word
+	query := preloadSenses(s.db.Model(&models.Word{})).Preload("Tags")
+	if includeProgress {
+		query = query.Preload("Cards", func(db *gorm.DB) *gorm.DB {
+			return db.Where("profile_id = ?", s.profileID).Order("ord")
+		})
+	}
+	if err := query.Find(&words).Error; err != nil {
+		return err
+	}
+	if includeProgress {
+		s.fillProgress(words)
+	}
+	// 关联单词按单词文本导出，不包含数据库ID
+	s.fillRelated(words)
+	for i := range words {
+		for j := range words[i].Related {
+			words[i].Related[j].ID = 0
+		}
+	}
 	wordList := models.WordList{Words: words}
 
 	file, err := os.Create(filePath)
@@ -187,34 +619,55 @@ func (s *WordService) ExportWords(filePath string) error {
 }
 
 // GetLearningStats 获取学习统计信息
-func (s *WordService) GetLearningStats() map[string]int {
+// deckID 不为0时只统计该单词本中的单词
+func (s *WordService) GetLearningStats(deckID int) map[string]int {
 	stats := make(map[string]int)
 
 	// 获取总单词数
 	var total int64
-	s.db.Model(&models.Word{}).Count(&total)
+	inDeck(s.db.Model(&models.Word{}), deckID, "id").Count(&total)
 	stats["total"] = int(total)
 
-	// 获取已学习单词数（review_count > 0）
+	// 以下单词级统计以识别卡片代表单词
+	recognition := func() *gorm.DB {
+		return inDeck(s.profileCards(s.db), deckID, "word_id").
+			Where("card_type = ?", models.CardTypeRecognition)
+	}
+
+	// 获取已学习单词数（识别卡片已开始学习）
 	var learned int64
-	s.db.Model(&models.Word{}).Where("review_count > 0").Count(&learned)
+	recognition().Where("state <> ?", models.StateNew).Count(&learned)
 	stats["learned"] = int(learned)
 
-	// 获取已掌握单词数（interval >= 30）
+	// 获取已掌握单词数（识别卡片间隔 >= 30天）
 	var mastered int64
-	s.db.Model(&models.Word{}).Where("interval >= 30").Count(&mastered)
+	recognition().Where("interval >= ?", models.MasteredInterval).Count(&mastered)
 	stats["mastered"] = int(mastered)
 
 	// 获取待复习单词数
 	var toReview int64
 	now := time.Now().Unix()
-	s.db.Model(&models.Word{}).Where("next_review <= ?", now).Count(&toReview)
+	recognition().Where("suspended = ? AND state <> ? AND next_review <= ?", false, models.StateNew, now).
+		Count(&toReview)
 	stats["toReview"] = int(toReview)
 
-	// 获取新单词数（review_count = 0）
+	// 获取新单词数（尚未学习）
 	var new int64
-	s.db.Model(&models.Word{}).Where("review_count = 0").Count(&new)
+	recognition().Where("state = ?", models.StateNew).Count(&new)
 	stats["new"] = int(new)
 
+	// 获取所有类型中已到期的卡片数
+	var dueCards int64
+	inDeck(s.profileCards(s.db), deckID, "word_id").
+		Where("suspended = ? AND state <> ? AND next_review <= ?", false, models.StateNew, now).
+		Count(&dueCards)
+	stats["dueCards"] = int(dueCards)
+
+	// 获取顽固词数（任一卡片为顽固卡片）
+	var leeches int64
+	inDeck(s.profileCards(s.db), deckID, "word_id").
+		Where("leech = ?", true).Distinct("word_id").Count(&leeches)
+	stats["leeches"] = int(leeches)
+
 	return stats
 }